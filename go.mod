@@ -4,5 +4,5 @@ go 1.12
 
 require (
 	github.com/hashicorp/terraform v0.12.6
-	github.com/stripe/stripe-go/v72 v72.107.0
+	github.com/stripe/stripe-go/v72 v72.122.0
 )
@@ -144,7 +144,7 @@ resource "stripe_price" "my_price" {
   nickname    = "my price"
   product     = stripe_product.my_product.id
   unit_amount = 1337
-  recurring = {
+  recurring {
     interval       = "month"
     interval_count = 1
     usage_type     = "licensed"
@@ -161,7 +161,7 @@ resource "stripe_price" "my_graduated_price" {
   nickname = "my graduated price"
   product  = stripe_product.my_product.id
 
-  recurring = {
+  recurring {
     interval       = "month"
     interval_count = 1
     usage_type     = "licensed"
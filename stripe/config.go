@@ -2,6 +2,9 @@ package stripe
 
 import (
 	"log"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/stripe/stripe-go/v72"
 	"github.com/stripe/stripe-go/v72/client"
@@ -10,17 +13,96 @@ import (
 // Config stores Stripe's API configuration
 type Config struct {
 	APIToken string
+
+	// APIBase, UploadsBase and ConnectBase override the default URLs of
+	// Stripe's backends when set, e.g. to go through a proxy or to talk to
+	// stripe-mock.
+	APIBase     string
+	UploadsBase string
+	ConnectBase string
+
+	// StripeAccount is the connected account resources are managed on,
+	// unless they specify their own.
+	StripeAccount string
+
+	// OnDestroy is what destroying objects Stripe can't delete does, unless
+	// resources specify their own.
+	OnDestroy string
+
+	// MaxNetworkRetries is how many times failed requests are retried,
+	// waiting between RetryMinBackoff and RetryMaxBackoff in between.
+	MaxNetworkRetries int
+	RetryMinBackoff   time.Duration
+	RetryMaxBackoff   time.Duration
+
+	// MaxRequestsPerSecond limits the rate of requests sent to Stripe when
+	// set.
+	MaxRequestsPerSecond int
+}
+
+// Client wraps Stripe's API client along with the provider-wide settings
+// resources have to honor.
+type Client struct {
+	*client.API
+
+	StripeAccount string
+	OnDestroy     string
+
+	// idempotencyKeys records the idempotency keys objects were created with
+	// during this run.
+	mu              sync.Mutex
+	idempotencyKeys map[string]bool
 }
 
 // Client returns a new Client for accessing Stripe.
-func (c *Config) Client() (*client.API, error) {
+func (c *Config) Client() (*Client, error) {
 	stripe.SetAppInfo(&stripe.AppInfo{
 		Name: "terraform-provider-stripe",
 	})
 
-	client := &client.API{}
-	client.Init(c.APIToken, nil)
+	// The backends share an HTTP client, so that the rate limit applies to
+	// all of them.
+	httpClient := &http.Client{
+		Transport: &retryTransport{
+			next:       http.DefaultTransport,
+			maxRetries: c.MaxNetworkRetries,
+			minBackoff: c.RetryMinBackoff,
+			maxBackoff: c.RetryMaxBackoff,
+			timeout:    80 * time.Second, // stripe-go's default
+			limiter:    newRateLimiter(c.MaxRequestsPerSecond),
+			sleep:      time.Sleep,
+		},
+	}
+
+	backends := &stripe.Backends{
+		API:     c.backend(stripe.APIBackend, c.APIBase, httpClient),
+		Uploads: c.backend(stripe.UploadsBackend, c.UploadsBase, httpClient),
+		Connect: c.backend(stripe.ConnectBackend, c.ConnectBase, httpClient),
+	}
+
+	api := &client.API{}
+	api.Init(c.APIToken, backends)
 	log.Printf("[INFO] Stripe Client configured.")
 
-	return client, nil
+	return &Client{
+		API:           api,
+		StripeAccount: c.StripeAccount,
+		OnDestroy:     c.OnDestroy,
+	}, nil
+}
+
+func (c *Config) backend(backendType stripe.SupportedBackend, url string, httpClient *http.Client) stripe.Backend {
+	config := &stripe.BackendConfig{
+		HTTPClient: httpClient,
+
+		// Retries are handled by httpClient's transport.
+		MaxNetworkRetries: stripe.Int64(0),
+	}
+
+	if url != "" {
+		log.Printf("[INFO] Using %s for Stripe's %s backend", url, backendType)
+		config.URL = stripe.String(url)
+	}
+
+	return stripe.GetBackendWithConfig(backendType, config)
 }
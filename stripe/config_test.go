@@ -0,0 +1,44 @@
+package stripe
+
+import (
+	"testing"
+
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestConfigClient_apiBase(t *testing.T) {
+	config := Config{
+		APIToken: "sk_test_mock",
+		APIBase:  testAccStripeMock.URL(),
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	product, err := client.Products.New(&stripe.ProductParams{
+		Name: stripe.String("Through api_base"),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if testAccStripeMock.Object("products", product.ID) == nil {
+		t.Fatalf("expected product %s to be created through the configured API base", product.ID)
+	}
+}
+
+func TestConfigClient_defaultBackends(t *testing.T) {
+	config := Config{APIToken: "sk_test_mock"}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	backend := client.Products.B.(*stripe.BackendImplementation)
+	if backend.URL != stripe.APIURL {
+		t.Fatalf("expected default API URL %s, got %s", stripe.APIURL, backend.URL)
+	}
+}
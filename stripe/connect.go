@@ -0,0 +1,58 @@
+package stripe
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Objects living on a connected account are tracked with an ID of the form
+// "<account>/<object ID>", so that refreshes and imports are made against
+// the right account. Objects of the platform account keep their plain ID.
+
+func stripeResourceID(account, id string) string {
+	if account == "" {
+		return id
+	}
+	return account + "/" + id
+}
+
+func parseStripeResourceID(id string) (string, string) {
+	if strings.HasPrefix(id, "acct_") {
+		if parts := strings.SplitN(id, "/", 2); len(parts) == 2 {
+			return parts[0], parts[1]
+		}
+	}
+	return "", id
+}
+
+// resourceStripeID returns the account and the Stripe ID of the object
+// tracked by a resource. Plain IDs, e.g. given on import, belong to the
+// provider's account.
+func resourceStripeID(d *schema.ResourceData, m interface{}) (string, string) {
+	account, id := parseStripeResourceID(d.Id())
+	if account == "" {
+		account = m.(*Client).StripeAccount
+	}
+	return account, id
+}
+
+// resourceStripeAccount returns the account a new object should be created
+// on: the resource's own `stripe_account`, or the provider's.
+func resourceStripeAccount(d *schema.ResourceData, m interface{}) string {
+	if account, ok := d.GetOk("stripe_account"); ok {
+		return account.(string)
+	}
+	return m.(*Client).StripeAccount
+}
+
+type stripeAccountSetter interface {
+	SetStripeAccount(string)
+}
+
+// setStripeAccount sets the Stripe-Account header of a request, if any.
+func setStripeAccount(params stripeAccountSetter, account string) {
+	if account != "" {
+		params.SetStripeAccount(account)
+	}
+}
@@ -0,0 +1,78 @@
+package stripe
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeCoupon() *schema.Resource {
+	dsSchema := dataSourceSchemaFromResourceSchema(resourceStripeCoupon().Schema)
+	dsSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	addOptionalFieldsToSchema(dsSchema, "stripe_account", "name", "metadata")
+
+	return &schema.Resource{
+		Read:   dataSourceStripeCouponRead,
+		Schema: dsSchema,
+	}
+}
+
+func dataSourceStripeCouponRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+
+	if id, ok := d.GetOk("id"); ok {
+		params := &stripe.CouponParams{}
+		params.AddExpand("applies_to")
+		params.AddExpand("currency_options")
+		setStripeAccount(params, account)
+		coupon, err := client.Coupons.Get(id.(string), params)
+		if err != nil {
+			return err
+		}
+
+		d.SetId(coupon.ID)
+		d.Set("stripe_account", account)
+		flattenCoupon(d, coupon)
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	var matches []*stripe.Coupon
+	listParams := &stripe.CouponListParams{}
+	listParams.AddExpand("data.applies_to")
+	listParams.AddExpand("data.currency_options")
+	setStripeAccount(listParams, account)
+	i := client.Coupons.List(listParams)
+	for i.Next() {
+		coupon := i.Coupon()
+		if name != "" && coupon.Name != name {
+			continue
+		}
+		if !metadataMatches(metadata, coupon.Metadata) {
+			continue
+		}
+		matches = append(matches, coupon)
+	}
+
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	if len(matches) != 1 {
+		return fmt.Errorf("expected exactly one coupon matching the given criteria, found %d", len(matches))
+	}
+
+	d.SetId(matches[0].ID)
+	d.Set("stripe_account", account)
+	flattenCoupon(d, matches[0])
+
+	return nil
+}
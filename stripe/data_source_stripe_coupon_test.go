@@ -0,0 +1,42 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceStripeCoupon_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStripeCouponConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.stripe_coupon.by_id", "id", "DATASOURCE10"),
+					resource.TestCheckResourceAttr("data.stripe_coupon.by_id", "percent_off", "10"),
+					resource.TestCheckResourceAttr("data.stripe_coupon.by_id", "duration", "forever"),
+					resource.TestCheckResourceAttr("data.stripe_coupon.by_name", "id", "DATASOURCE10"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceStripeCouponConfig = `
+resource "stripe_coupon" "test" {
+  code        = "DATASOURCE10"
+  name        = "Data Source Coupon"
+  duration    = "forever"
+  percent_off = 10
+}
+
+data "stripe_coupon" "by_id" {
+  id = stripe_coupon.test.id
+}
+
+data "stripe_coupon" "by_name" {
+  name = stripe_coupon.test.name
+}
+`
@@ -0,0 +1,81 @@
+package stripe
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripePlan() *schema.Resource {
+	dsSchema := dataSourceSchemaFromResourceSchema(resourceStripePlan().Schema)
+	dsSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	addOptionalFieldsToSchema(dsSchema, "stripe_account", "product", "nickname", "metadata")
+
+	return &schema.Resource{
+		Read:   dataSourceStripePlanRead,
+		Schema: dsSchema,
+	}
+}
+
+func dataSourceStripePlanRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+
+	if id, ok := d.GetOk("id"); ok {
+		params := &stripe.PlanParams{}
+		params.AddExpand("tiers")
+		setStripeAccount(params, account)
+		plan, err := client.Plans.Get(id.(string), params)
+		if err != nil {
+			return err
+		}
+
+		d.SetId(plan.ID)
+		d.Set("stripe_account", account)
+		flattenPlan(d, plan)
+		return nil
+	}
+
+	params := &stripe.PlanListParams{}
+	params.AddExpand("data.tiers")
+	setStripeAccount(params, account)
+
+	if product, ok := d.GetOk("product"); ok {
+		params.Product = stripe.String(product.(string))
+	}
+
+	nickname := d.Get("nickname").(string)
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	var matches []*stripe.Plan
+	i := client.Plans.List(params)
+	for i.Next() {
+		plan := i.Plan()
+		if nickname != "" && plan.Nickname != nickname {
+			continue
+		}
+		if !metadataMatches(metadata, plan.Metadata) {
+			continue
+		}
+		matches = append(matches, plan)
+	}
+
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	if len(matches) != 1 {
+		return fmt.Errorf("expected exactly one plan matching the given criteria, found %d", len(matches))
+	}
+
+	d.SetId(matches[0].ID)
+	d.Set("stripe_account", account)
+	flattenPlan(d, matches[0])
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceStripePlan_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStripePlanConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.stripe_plan.by_id", "id", "stripe_plan.test", "id"),
+					resource.TestCheckResourceAttr("data.stripe_plan.by_id", "amount", "999"),
+					resource.TestCheckResourceAttr("data.stripe_plan.by_id", "interval", "year"),
+					resource.TestCheckResourceAttrPair("data.stripe_plan.by_nickname", "id", "stripe_plan.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceStripePlanConfig = `
+resource "stripe_product" "test" {
+  name = "Data Source Plan Product"
+  type = "service"
+}
+
+resource "stripe_plan" "test" {
+  product  = stripe_product.test.id
+  nickname = "Data Source Plan"
+  amount   = 999
+  interval = "year"
+  currency = "usd"
+}
+
+data "stripe_plan" "by_id" {
+  id = stripe_plan.test.id
+}
+
+data "stripe_plan" "by_nickname" {
+  product  = stripe_plan.test.product
+  nickname = stripe_plan.test.nickname
+}
+`
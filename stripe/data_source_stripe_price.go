@@ -0,0 +1,91 @@
+package stripe
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripePrice() *schema.Resource {
+	dsSchema := dataSourceSchemaFromResourceSchema(resourceStripePrice().Schema)
+	dsSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	addOptionalFieldsToSchema(dsSchema, "stripe_account", "lookup_key", "product", "currency", "nickname", "metadata")
+
+	return &schema.Resource{
+		Read:   dataSourceStripePriceRead,
+		Schema: dsSchema,
+	}
+}
+
+func dataSourceStripePriceRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+
+	if id, ok := d.GetOk("id"); ok {
+		params := &stripe.PriceParams{}
+		params.AddExpand("tiers")
+		params.AddExpand("currency_options")
+		setStripeAccount(params, account)
+		price, err := client.Prices.Get(id.(string), params)
+		if err != nil {
+			return err
+		}
+
+		d.SetId(price.ID)
+		d.Set("stripe_account", account)
+		flattenPrice(d, price)
+		return nil
+	}
+
+	params := &stripe.PriceListParams{}
+	params.AddExpand("data.tiers")
+	params.AddExpand("data.currency_options")
+	setStripeAccount(params, account)
+
+	if lookupKey, ok := d.GetOk("lookup_key"); ok {
+		params.LookupKeys = stripe.StringSlice([]string{lookupKey.(string)})
+	}
+
+	if product, ok := d.GetOk("product"); ok {
+		params.Product = stripe.String(product.(string))
+	}
+
+	if currency, ok := d.GetOk("currency"); ok {
+		params.Currency = stripe.String(currency.(string))
+	}
+
+	nickname := d.Get("nickname").(string)
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	var matches []*stripe.Price
+	i := client.Prices.List(params)
+	for i.Next() {
+		price := i.Price()
+		if nickname != "" && price.Nickname != nickname {
+			continue
+		}
+		if !metadataMatches(metadata, price.Metadata) {
+			continue
+		}
+		matches = append(matches, price)
+	}
+
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	if len(matches) != 1 {
+		return fmt.Errorf("expected exactly one price matching the given criteria, found %d", len(matches))
+	}
+
+	d.SetId(matches[0].ID)
+	d.Set("stripe_account", account)
+	flattenPrice(d, matches[0])
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceStripePrice_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStripePriceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.stripe_price.by_id", "id", "stripe_price.test", "id"),
+					resource.TestCheckResourceAttr("data.stripe_price.by_id", "unit_amount", "2500"),
+					resource.TestCheckResourceAttr("data.stripe_price.by_id", "currency", "eur"),
+					resource.TestCheckResourceAttrPair("data.stripe_price.by_id", "product", "stripe_product.test", "id"),
+					resource.TestCheckResourceAttrPair("data.stripe_price.by_product", "id", "stripe_price.test", "id"),
+					resource.TestCheckResourceAttr("data.stripe_price.by_product", "nickname", "Data Source Price"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceStripePriceConfig = `
+resource "stripe_product" "test" {
+  name = "Data Source Price Product"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  nickname    = "Data Source Price"
+  currency    = "eur"
+  unit_amount = 2500
+
+  metadata = {
+    catalog = "data-source-price"
+  }
+}
+
+data "stripe_price" "by_id" {
+  id = stripe_price.test.id
+}
+
+data "stripe_price" "by_product" {
+  product  = stripe_price.test.product
+  currency = "eur"
+
+  metadata = {
+    catalog = stripe_price.test.metadata.catalog
+  }
+}
+`
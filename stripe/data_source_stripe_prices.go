@@ -0,0 +1,121 @@
+package stripe
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripePrices() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStripePricesRead,
+
+		Schema: map[string]*schema.Schema{
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"product": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"currency": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"one_time", "recurring"}, false),
+			},
+			"lookup_keys": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"stripe_account": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"prices": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     dataSourceListElem(resourceStripePrice()),
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripePricesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.PriceListParams{}
+	setStripeAccount(params, account)
+	params.Limit = stripe.Int64(100)
+	params.AddExpand("data.tiers")
+	params.AddExpand("data.currency_options")
+
+	if active, ok := d.GetOkExists("active"); ok {
+		params.Active = stripe.Bool(active.(bool))
+	}
+
+	if product, ok := d.GetOk("product"); ok {
+		params.Product = stripe.String(product.(string))
+	}
+
+	if currency, ok := d.GetOk("currency"); ok {
+		params.Currency = stripe.String(currency.(string))
+	}
+
+	if priceType, ok := d.GetOk("type"); ok {
+		params.Type = stripe.String(priceType.(string))
+	}
+
+	params.LookupKeys = expandStringList(d, "lookup_keys")
+
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	ids := []string{}
+	prices := []map[string]interface{}{}
+	i := client.Prices.List(params)
+	for i.Next() {
+		price := i.Price()
+		if !metadataMatches(metadata, price.Metadata) {
+			continue
+		}
+		ids = append(ids, price.ID)
+		prices = append(prices, flattenToMap(resourceStripePrice(), price.ID, func(rd *schema.ResourceData) {
+			rd.Set("stripe_account", account)
+			flattenPrice(rd, price)
+		}))
+	}
+
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	d.Set("stripe_account", account)
+	d.Set("ids", ids)
+	d.Set("prices", prices)
+
+	return nil
+}
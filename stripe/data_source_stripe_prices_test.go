@@ -0,0 +1,70 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceStripePrices_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStripePricesConfig,
+			},
+			{
+				Config: testAccDataSourceStripePricesConfig + `
+data "stripe_prices" "active" {
+  product = stripe_product.test.id
+  active  = true
+}
+
+data "stripe_prices" "usd" {
+  product  = stripe_product.test.id
+  currency = "usd"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.stripe_prices.active", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.stripe_prices.active", "prices.#", "2"),
+					resource.TestCheckResourceAttr("data.stripe_prices.usd", "ids.#", "2"),
+					resource.TestCheckResourceAttrPair("data.stripe_prices.usd", "ids.0", "stripe_price.inactive", "id"),
+					resource.TestCheckResourceAttrPair("data.stripe_prices.usd", "ids.1", "stripe_price.usd", "id"),
+					resource.TestCheckResourceAttr("data.stripe_prices.usd", "prices.1.unit_amount", "1000"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceStripePricesConfig = `
+resource "stripe_product" "test" {
+  name = "Data Source Prices Product"
+  type = "service"
+}
+
+resource "stripe_price" "usd" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1000
+}
+
+resource "stripe_price" "eur" {
+  product     = stripe_product.test.id
+  currency    = "eur"
+  unit_amount = 900
+
+  depends_on = [stripe_price.usd]
+}
+
+resource "stripe_price" "inactive" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 500
+  active      = false
+
+  depends_on = [stripe_price.eur]
+}
+`
@@ -0,0 +1,73 @@
+package stripe
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeProduct() *schema.Resource {
+	dsSchema := dataSourceSchemaFromResourceSchema(resourceStripeProduct().Schema)
+	dsSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	addOptionalFieldsToSchema(dsSchema, "stripe_account", "name", "metadata")
+
+	return &schema.Resource{
+		Read:   dataSourceStripeProductRead,
+		Schema: dsSchema,
+	}
+}
+
+func dataSourceStripeProductRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+
+	if id, ok := d.GetOk("id"); ok {
+		params := &stripe.ProductParams{}
+		setStripeAccount(params, account)
+		product, err := client.Products.Get(id.(string), params)
+		if err != nil {
+			return err
+		}
+
+		d.SetId(product.ID)
+		d.Set("stripe_account", account)
+		return flattenProduct(d, product, product.LastResponse)
+	}
+
+	name := d.Get("name").(string)
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	var matches []*stripe.Product
+	var responses []*stripe.APIResponse
+	listParams := &stripe.ProductListParams{}
+	setStripeAccount(listParams, account)
+	i := client.Products.List(listParams)
+	for i.Next() {
+		product := i.Product()
+		if name != "" && product.Name != name {
+			continue
+		}
+		if !metadataMatches(metadata, product.Metadata) {
+			continue
+		}
+		matches = append(matches, product)
+		responses = append(responses, i.ProductList().LastResponse)
+	}
+
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	if len(matches) != 1 {
+		return fmt.Errorf("expected exactly one product matching the given criteria, found %d", len(matches))
+	}
+
+	d.SetId(matches[0].ID)
+	d.Set("stripe_account", account)
+	return flattenProduct(d, matches[0], responses[0])
+}
@@ -0,0 +1,78 @@
+package stripe
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceStripeProduct_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStripeProductConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.stripe_product.by_id", "id", "stripe_product.test", "id"),
+					resource.TestCheckResourceAttr("data.stripe_product.by_id", "name", "Data Source Product"),
+					resource.TestCheckResourceAttr("data.stripe_product.by_id", "unit_label", "per seat"),
+					resource.TestCheckResourceAttr("data.stripe_product.by_id", "feature.0.name", "SSO"),
+					resource.TestCheckResourceAttrPair("data.stripe_product.by_name", "id", "stripe_product.test", "id"),
+					resource.TestCheckResourceAttr("data.stripe_product.by_name", "feature.#", "1"),
+					resource.TestCheckResourceAttr("data.stripe_product.by_name", "feature.0.name", "SSO"),
+					resource.TestCheckResourceAttrPair("data.stripe_product.by_metadata", "id", "stripe_product.test", "id"),
+					resource.TestCheckResourceAttr("data.stripe_product.by_metadata", "metadata.catalog", "data-source-product"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceStripeProduct_noMatch(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "stripe_product" "test" {
+  name = "No Such Product"
+}
+`,
+				ExpectError: regexp.MustCompile("expected exactly one product matching the given criteria, found 0"),
+			},
+		},
+	})
+}
+
+const testAccDataSourceStripeProductConfig = `
+resource "stripe_product" "test" {
+  name       = "Data Source Product"
+  type       = "service"
+  unit_label = "per seat"
+
+  feature {
+    name = "SSO"
+  }
+
+  metadata = {
+    catalog = "data-source-product"
+  }
+}
+
+data "stripe_product" "by_id" {
+  id = stripe_product.test.id
+}
+
+data "stripe_product" "by_name" {
+  name = stripe_product.test.name
+}
+
+data "stripe_product" "by_metadata" {
+  metadata = {
+    catalog = stripe_product.test.metadata.catalog
+  }
+}
+`
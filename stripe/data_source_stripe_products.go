@@ -0,0 +1,98 @@
+package stripe
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeProducts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStripeProductsRead,
+
+		Schema: map[string]*schema.Schema{
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"stripe_account": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"products": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     dataSourceListElem(resourceStripeProduct()),
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripeProductsRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.ProductListParams{}
+	setStripeAccount(params, account)
+	params.Limit = stripe.Int64(100)
+
+	if active, ok := d.GetOkExists("active"); ok {
+		params.Active = stripe.Bool(active.(bool))
+	}
+
+	if productType, ok := d.GetOk("type"); ok {
+		params.Type = stripe.String(productType.(string))
+	}
+
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	ids := []string{}
+	products := []map[string]interface{}{}
+	i := client.Products.List(params)
+	for i.Next() {
+		product := i.Product()
+		if !metadataMatches(metadata, product.Metadata) {
+			continue
+		}
+		var err error
+		ids = append(ids, product.ID)
+		products = append(products, flattenToMap(resourceStripeProduct(), product.ID, func(rd *schema.ResourceData) {
+			rd.Set("stripe_account", account)
+			err = flattenProduct(rd, product, i.ProductList().LastResponse)
+		}))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	d.Set("stripe_account", account)
+	d.Set("ids", ids)
+	d.Set("products", products)
+
+	return nil
+}
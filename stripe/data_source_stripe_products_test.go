@@ -0,0 +1,77 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceStripeProducts_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStripeProductsConfig,
+			},
+			{
+				Config: testAccDataSourceStripeProductsConfig + `
+data "stripe_products" "test" {
+  active = true
+
+  metadata = {
+    catalog = "data-source-products"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.stripe_products.test", "ids.#", "2"),
+					resource.TestCheckResourceAttrPair("data.stripe_products.test", "ids.0", "stripe_product.second", "id"),
+					resource.TestCheckResourceAttrPair("data.stripe_products.test", "ids.1", "stripe_product.first", "id"),
+					resource.TestCheckResourceAttr("data.stripe_products.test", "products.#", "2"),
+					resource.TestCheckResourceAttr("data.stripe_products.test", "products.0.name", "Second"),
+					resource.TestCheckResourceAttr("data.stripe_products.test", "products.0.feature.#", "1"),
+					resource.TestCheckResourceAttr("data.stripe_products.test", "products.0.feature.0.name", "Audit log"),
+					resource.TestCheckResourceAttr("data.stripe_products.test", "products.1.feature.#", "0"),
+					resource.TestCheckResourceAttr("data.stripe_products.test", "products.1.name", "First"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceStripeProductsConfig = `
+resource "stripe_product" "first" {
+  name = "First"
+  type = "service"
+
+  metadata = {
+    catalog = "data-source-products"
+  }
+}
+
+resource "stripe_product" "second" {
+  name = "Second"
+  type = "service"
+
+  feature {
+    name = "Audit log"
+  }
+
+  metadata = {
+    catalog = "data-source-products"
+  }
+
+  depends_on = [stripe_product.first]
+}
+
+resource "stripe_product" "archived" {
+  name   = "Archived"
+  type   = "service"
+  active = false
+
+  metadata = {
+    catalog = "data-source-products"
+  }
+}
+`
@@ -0,0 +1,78 @@
+package stripe
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeTaxRate() *schema.Resource {
+	dsSchema := dataSourceSchemaFromResourceSchema(resourceStripeTaxRate().Schema)
+	dsSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	addOptionalFieldsToSchema(dsSchema, "stripe_account", "display_name", "jurisdiction", "metadata")
+
+	return &schema.Resource{
+		Read:   dataSourceStripeTaxRateRead,
+		Schema: dsSchema,
+	}
+}
+
+func dataSourceStripeTaxRateRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+
+	if id, ok := d.GetOk("id"); ok {
+		params := &stripe.TaxRateParams{}
+		setStripeAccount(params, account)
+		taxRate, err := client.TaxRates.Get(id.(string), params)
+		if err != nil {
+			return err
+		}
+
+		d.SetId(taxRate.ID)
+		d.Set("stripe_account", account)
+		flattenTaxRate(d, taxRate)
+		return nil
+	}
+
+	displayName := d.Get("display_name").(string)
+	jurisdiction := d.Get("jurisdiction").(string)
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	var matches []*stripe.TaxRate
+	listParams := &stripe.TaxRateListParams{}
+	setStripeAccount(listParams, account)
+	i := client.TaxRates.List(listParams)
+	for i.Next() {
+		taxRate := i.TaxRate()
+		if displayName != "" && taxRate.DisplayName != displayName {
+			continue
+		}
+		if jurisdiction != "" && taxRate.Jurisdiction != jurisdiction {
+			continue
+		}
+		if !metadataMatches(metadata, taxRate.Metadata) {
+			continue
+		}
+		matches = append(matches, taxRate)
+	}
+
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	if len(matches) != 1 {
+		return fmt.Errorf("expected exactly one tax rate matching the given criteria, found %d", len(matches))
+	}
+
+	d.SetId(matches[0].ID)
+	d.Set("stripe_account", account)
+	flattenTaxRate(d, matches[0])
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceStripeTaxRate_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStripeTaxRateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.stripe_tax_rate.by_id", "id", "stripe_tax_rate.test", "id"),
+					resource.TestCheckResourceAttr("data.stripe_tax_rate.by_id", "percentage", "7.5"),
+					resource.TestCheckResourceAttr("data.stripe_tax_rate.by_id", "inclusive", "true"),
+					resource.TestCheckResourceAttrPair("data.stripe_tax_rate.by_display_name", "id", "stripe_tax_rate.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceStripeTaxRateConfig = `
+resource "stripe_tax_rate" "test" {
+  display_name = "Data Source Sales Tax"
+  jurisdiction = "DS"
+  percentage   = 7.5
+  inclusive    = true
+  active       = true
+}
+
+data "stripe_tax_rate" "by_id" {
+  id = stripe_tax_rate.test.id
+}
+
+data "stripe_tax_rate" "by_display_name" {
+  display_name = stripe_tax_rate.test.display_name
+  jurisdiction = stripe_tax_rate.test.jurisdiction
+}
+`
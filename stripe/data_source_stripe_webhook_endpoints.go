@@ -0,0 +1,78 @@
+package stripe
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func dataSourceStripeWebhookEndpoints() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStripeWebhookEndpointsRead,
+
+		Schema: map[string]*schema.Schema{
+			"stripe_account": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"webhook_endpoints": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     dataSourceListElem(resourceStripeWebhookEndpoint()),
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStripeWebhookEndpointsRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.WebhookEndpointListParams{}
+	setStripeAccount(params, account)
+	params.Limit = stripe.Int64(100)
+
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	ids := []string{}
+	webhookEndpoints := []map[string]interface{}{}
+	i := client.WebhookEndpoints.List(params)
+	for i.Next() {
+		webhookEndpoint := i.WebhookEndpoint()
+		if !metadataMatches(metadata, webhookEndpoint.Metadata) {
+			continue
+		}
+		ids = append(ids, webhookEndpoint.ID)
+		webhookEndpoints = append(webhookEndpoints, flattenToMap(resourceStripeWebhookEndpoint(), webhookEndpoint.ID, func(rd *schema.ResourceData) {
+			rd.Set("stripe_account", account)
+			flattenWebhookEndpoint(rd, webhookEndpoint)
+		}))
+	}
+
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	d.Set("stripe_account", account)
+	d.Set("ids", ids)
+	d.Set("webhook_endpoints", webhookEndpoints)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package stripe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccDataSourceStripeWebhookEndpoints_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStripeWebhookEndpointsConfig,
+			},
+			{
+				Config: testAccDataSourceStripeWebhookEndpointsConfig + `
+data "stripe_webhook_endpoints" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.stripe_webhook_endpoints.test", "ids.0", "stripe_webhook_endpoint.test", "id"),
+					resource.TestCheckResourceAttr("data.stripe_webhook_endpoints.test", "webhook_endpoints.0.url", "https://example.com/data-source"),
+					resource.TestCheckResourceAttr("data.stripe_webhook_endpoints.test", "webhook_endpoints.0."+testAccStripeWebhookEndpointEvent("invoice.paid"), "invoice.paid"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceStripeWebhookEndpoints_sensitiveSecret(t *testing.T) {
+	elem := dataSourceStripeWebhookEndpoints().Schema["webhook_endpoints"].Elem.(*schema.Resource)
+	if !elem.Schema["secret"].Sensitive {
+		t.Fatal("webhook endpoints' secret should be sensitive")
+	}
+}
+
+const testAccDataSourceStripeWebhookEndpointsConfig = `
+resource "stripe_webhook_endpoint" "test" {
+  url            = "https://example.com/data-source"
+  enabled_events = ["invoice.paid"]
+}
+`
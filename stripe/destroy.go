@@ -0,0 +1,73 @@
+package stripe
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Some objects can't be deleted through Stripe's API. `on_destroy` controls
+// what destroying them does:
+//
+//   - error: fail, so that they get deleted from the dashboard (the default)
+//   - archive: deactivate them, i.e. set `active` to false
+//   - forget: only drop them from the state
+const (
+	onDestroyError   = "error"
+	onDestroyArchive = "archive"
+	onDestroyForget  = "forget"
+)
+
+var validateOnDestroy = validation.StringInSlice([]string{onDestroyError, onDestroyArchive, onDestroyForget}, false)
+
+func onDestroySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateOnDestroy,
+	}
+}
+
+// resourceStripeOnDestroy returns the resource's own `on_destroy`, or the
+// provider's, or the fallback of the resource's type when neither is set.
+func resourceStripeOnDestroy(d *schema.ResourceData, m interface{}, fallback string) string {
+	if onDestroy, ok := d.GetOk("on_destroy"); ok {
+		return onDestroy.(string)
+	}
+	if onDestroy := m.(*Client).OnDestroy; onDestroy != "" {
+		return onDestroy
+	}
+	return fallback
+}
+
+// resourceStripeUndeletableDelete implements the Delete of a resource whose
+// object can't be deleted, archiving it with the given function if asked to.
+func resourceStripeUndeletableDelete(d *schema.ResourceData, m interface{}, kind string, archive func() error) error {
+	return resourceStripeOnDestroyDelete(d, m, kind, onDestroyError, archive)
+}
+
+// resourceStripeArchivableDelete is resourceStripeUndeletableDelete for
+// objects that are safe to archive, which are archived unless `on_destroy` is
+// set otherwise.
+func resourceStripeArchivableDelete(d *schema.ResourceData, m interface{}, kind string, archive func() error) error {
+	return resourceStripeOnDestroyDelete(d, m, kind, onDestroyArchive, archive)
+}
+
+func resourceStripeOnDestroyDelete(d *schema.ResourceData, m interface{}, kind, fallback string, archive func() error) error {
+	switch resourceStripeOnDestroy(d, m, fallback) {
+	case onDestroyArchive:
+		if err := archive(); err != nil {
+			return err
+		}
+		log.Printf("[INFO] Archived %s: %s", kind, d.Id())
+	case onDestroyForget:
+		log.Printf("[INFO] Forgetting %s: %s, it still exists in Stripe", kind, d.Id())
+	default:
+		return fmt.Errorf("[WARNING] Stripe doesn't allow deleting %ss via the API. Your state file contains at least one (\"%v\") that needs deletion. Please remove it manually, or set on_destroy to \"archive\" or \"forget\".", kind, d.Id())
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,127 @@
+package stripe
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/form"
+)
+
+// Objects are created with an idempotency key derived from what's being
+// created, rather than a random one, so that applying again after a create
+// whose outcome is unknown, e.g. a timeout once retries are exhausted, returns
+// the object that was created instead of a duplicate Terraform doesn't track.
+//
+// Stripe keeps idempotency keys for 24 hours. Within that window, a key can
+// also be replayed for objects that were created on purpose with the same
+// arguments: these are told apart by the object being gone by now, e.g. after
+// a destroy, or by the key having been used already during this run, e.g.
+// with count, in which case a new key is derived from the previous one.
+
+// idempotencyKey derives the idempotency key of a create call from the
+// collection it's made to, the account it's made on and its parameters.
+func idempotencyKey(path string, params stripe.ParamsContainer) string {
+	values := &form.Values{}
+	form.AppendTo(values, params)
+
+	account := ""
+	if p := params.GetParams(); p.StripeAccount != nil {
+		account = *p.StripeAccount
+	}
+
+	return chainIdempotencyKey(path+"\n"+account, values.ToValues().Encode())
+}
+
+func chainIdempotencyKey(key, suffix string) string {
+	return fmt.Sprintf("terraform-%x", sha256.Sum256([]byte(key+"\n"+suffix)))
+}
+
+// claimIdempotencyKey returns key, or a key derived from it if it was already
+// used to create another object during this run.
+func (c *Client) claimIdempotencyKey(key string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.idempotencyKeys == nil {
+		c.idempotencyKeys = map[string]bool{}
+	}
+	for c.idempotencyKeys[key] {
+		key = chainIdempotencyKey(key, "next")
+	}
+	c.idempotencyKeys[key] = true
+	return key
+}
+
+// createIdempotently calls create with an idempotency key derived from
+// params. create returns the ID of the object and the response it was read
+// from.
+func createIdempotently(client *Client, path string, params stripe.ParamsContainer, create func() (string, *stripe.APIResponse, error)) error {
+	key := idempotencyKey(path, params)
+
+	for {
+		key = client.claimIdempotencyKey(key)
+		params.GetParams().IdempotencyKey = stripe.String(key)
+
+		id, resp, err := create()
+		if err != nil || resp == nil || resp.Header.Get("Idempotent-Replayed") != "true" {
+			return err
+		}
+
+		gone, err := idempotentObjectGone(client, path, id, params)
+		if err != nil {
+			return err
+		}
+		if !gone {
+			log.Printf("[INFO] Stripe object %s was created by an earlier request with the same arguments", id)
+			return nil
+		}
+
+		log.Printf("[INFO] Stripe object %s was created by an earlier request with the same arguments but is gone, creating a new one", id)
+		key = chainIdempotencyKey(key, id)
+	}
+}
+
+// idempotentObject holds the attributes telling whether an object is still
+// in use.
+type idempotentObject struct {
+	stripe.APIResource
+
+	Active  *bool  `json:"active"`
+	Deleted bool   `json:"deleted"`
+	Status  string `json:"status"`
+}
+
+// idempotentObjectGone reports whether the object an idempotency key was
+// replayed for has been deleted, archived or canceled since it was created.
+// Objects that were created inactive on purpose aren't gone.
+func idempotentObjectGone(client *Client, path, id string, params stripe.ParamsContainer) (bool, error) {
+	getParams := &stripe.Params{StripeAccount: params.GetParams().StripeAccount}
+	obj := &idempotentObject{}
+	// The API's clients share their backend, any of them can fetch objects
+	// of other collections.
+	err := client.Products.B.Call(http.MethodGet, "/v1/"+path+"/"+id, client.Products.Key, getParams, obj)
+	if stripeErr, ok := err.(*stripe.Error); ok && stripeErr.HTTPStatusCode == http.StatusNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	values := &form.Values{}
+	form.AppendTo(values, params)
+	active := values.Get("active")
+	createdInactive := len(active) > 0 && active[0] == "false"
+
+	switch {
+	case obj.Deleted:
+		return true, nil
+	case obj.Active != nil && !*obj.Active && !createdInactive:
+		return true, nil
+	case obj.Status == "canceled" || obj.Status == "released" || obj.Status == "incomplete_expired":
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,143 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	key := func(path, account string, metadata map[string]string) string {
+		params := &stripe.ProductParams{Name: stripe.String("Platform")}
+		params.Metadata = metadata
+		setStripeAccount(params, account)
+		return idempotencyKey(path, params)
+	}
+
+	base := key("products", "", map[string]string{"a": "1", "b": "2", "c": "3"})
+	if again := key("products", "", map[string]string{"c": "3", "b": "2", "a": "1"}); again != base {
+		t.Fatalf("expected the same arguments to give the same key, got %s and %s", base, again)
+	}
+
+	for name, other := range map[string]string{
+		"collection": key("prices", "", map[string]string{"a": "1", "b": "2", "c": "3"}),
+		"account":    key("products", "acct_1032D82eZvKYlo2C", map[string]string{"a": "1", "b": "2", "c": "3"}),
+		"arguments":  key("products", "", map[string]string{"a": "1", "b": "2"}),
+	} {
+		if other == base {
+			t.Errorf("expected a different %s to give a different key", name)
+		}
+	}
+
+	if len(base) > 255 {
+		t.Fatalf("expected key to fit in 255 characters, got %d", len(base))
+	}
+}
+
+func TestAccStripePrice_lostCreateResponse(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeIdempotentProductConfig,
+			},
+			{
+				// The price is created but the response is lost.
+				PreConfig:   testAccStripeMock.LoseNextResponse,
+				Config:      testAccStripeIdempotentPriceConfig,
+				ExpectError: regexp.MustCompile(`The connection was lost`),
+			},
+			{
+				// Applying again picks the price that was created up.
+				Config: testAccStripeIdempotentPriceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeProductPrices("stripe_product.test", 1),
+					resource.TestCheckResourceAttrPair("stripe_price.test", "product", "stripe_product.test", "id"),
+					resource.TestCheckResourceAttr("stripe_price.test", "unit_amount", "4200"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_identicalCount(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeIdempotentProductConfig + `
+resource "stripe_price" "test" {
+  count       = 2
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 4200
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeProductPrices("stripe_product.test", 2),
+					testAccCheckResourceAttrDiffer("stripe_price.test.0", "stripe_price.test.1", "id"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckStripeProductPrices checks how many active prices the product
+// has in Stripe.
+func testAccCheckStripeProductPrices(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		found := 0
+		for _, price := range testAccStripeMock.Objects("prices") {
+			if price["product"] == rs.Primary.ID && price["active"] == true {
+				found++
+			}
+		}
+		if found != count {
+			return fmt.Errorf("expected product %s to have %d prices, got %d", rs.Primary.ID, count, found)
+		}
+		return nil
+	}
+}
+
+func testAccCheckResourceAttrDiffer(first, second, key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		a, ok := s.RootModule().Resources[first]
+		if !ok {
+			return fmt.Errorf("not found: %s", first)
+		}
+		b, ok := s.RootModule().Resources[second]
+		if !ok {
+			return fmt.Errorf("not found: %s", second)
+		}
+
+		if a.Primary.Attributes[key] == b.Primary.Attributes[key] {
+			return fmt.Errorf("expected %s and %s to have different %s, both are %s", first, second, key, a.Primary.Attributes[key])
+		}
+		return nil
+	}
+}
+
+const testAccStripeIdempotentProductConfig = `
+resource "stripe_product" "test" {
+  name = "Idempotent"
+}
+`
+
+const testAccStripeIdempotentPriceConfig = testAccStripeIdempotentProductConfig + `
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 4200
+}
+`
@@ -1,9 +1,13 @@
 package stripe
 
 import (
+	"fmt"
 	"log"
+	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -16,16 +20,87 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("STRIPE_API_TOKEN", nil),
 			},
+			"api_base": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_API_BASE", ""),
+				ValidateFunc: validateBaseURL,
+			},
+			"uploads_base": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_UPLOADS_BASE", ""),
+				ValidateFunc: validateBaseURL,
+			},
+			"connect_base": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_CONNECT_BASE", ""),
+				ValidateFunc: validateBaseURL,
+			},
+			"stripe_account": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_ACCOUNT", ""),
+				ValidateFunc: validateStripeAccount,
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_ON_DESTROY", nil),
+				ValidateFunc: validateOnDestroy,
+			},
+			"max_network_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_MAX_NETWORK_RETRIES", 2),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_min_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_RETRY_MIN_BACKOFF", "500ms"),
+				ValidateFunc: validateDuration,
+			},
+			"retry_max_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_RETRY_MAX_BACKOFF", "5s"),
+				ValidateFunc: validateDuration,
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("STRIPE_MAX_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"stripe_coupon":            dataSourceStripeCoupon(),
+			"stripe_plan":              dataSourceStripePlan(),
+			"stripe_price":             dataSourceStripePrice(),
+			"stripe_prices":            dataSourceStripePrices(),
+			"stripe_product":           dataSourceStripeProduct(),
+			"stripe_products":          dataSourceStripeProducts(),
+			"stripe_tax_rate":          dataSourceStripeTaxRate(),
+			"stripe_webhook_endpoints": dataSourceStripeWebhookEndpoints(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"stripe_coupon":           resourceStripeCoupon(),
-			"stripe_plan":             resourceStripePlan(),
-			"stripe_price":            resourceStripePrice(),
-			"stripe_product":          resourceStripeProduct(),
-			"stripe_tax_rate":         resourceStripeTaxRate(),
-			"stripe_webhook_endpoint": resourceStripeWebhookEndpoint(),
-			"stripe_customer_portal":  resourceCustomerPortal(),
+			"stripe_coupon":                resourceStripeCoupon(),
+			"stripe_plan":                  resourceStripePlan(),
+			"stripe_price":                 resourceStripePrice(),
+			"stripe_product":               resourceStripeProduct(),
+			"stripe_promotion_code":        resourceStripePromotionCode(),
+			"stripe_tax_rate":              resourceStripeTaxRate(),
+			"stripe_webhook_endpoint":      resourceStripeWebhookEndpoint(),
+			"stripe_customer":              resourceStripeCustomer(),
+			"stripe_subscription":          resourceStripeSubscription(),
+			"stripe_shipping_rate":         resourceStripeShippingRate(),
+			"stripe_payment_link":          resourceStripePaymentLink(),
+			"stripe_subscription_schedule": resourceStripeSubscriptionSchedule(),
+			"stripe_customer_portal":       resourceCustomerPortal(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -34,9 +109,46 @@ func Provider() terraform.ResourceProvider {
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
-		APIToken: d.Get("api_token").(string),
+		APIToken:    d.Get("api_token").(string),
+		APIBase:     d.Get("api_base").(string),
+		UploadsBase: d.Get("uploads_base").(string),
+		ConnectBase: d.Get("connect_base").(string),
+
+		StripeAccount: d.Get("stripe_account").(string),
+		OnDestroy:     d.Get("on_destroy").(string),
+
+		MaxNetworkRetries:    d.Get("max_network_retries").(int),
+		MaxRequestsPerSecond: d.Get("max_requests_per_second").(int),
+	}
+
+	// Durations are validated by the schema already.
+	config.RetryMinBackoff, _ = time.ParseDuration(d.Get("retry_min_backoff").(string))
+	config.RetryMaxBackoff, _ = time.ParseDuration(d.Get("retry_max_backoff").(string))
+	if config.RetryMinBackoff > config.RetryMaxBackoff {
+		return nil, fmt.Errorf("retry_min_backoff (%s) can't be greater than retry_max_backoff (%s)", config.RetryMinBackoff, config.RetryMaxBackoff)
 	}
 
 	log.Println("[INFO] Initializing Stripe client")
 	return config.Client()
 }
+
+var validateBaseURL = validation.StringMatch(regexp.MustCompile(`^https?://.+[^/]$`), "must be an http(s) URL without a trailing slash, e.g. http://localhost:12111")
+
+var validateStripeAccount = validation.StringMatch(regexp.MustCompile(`^(acct_\w+)?$`), "must be the ID of a connected account, e.g. acct_1032D82eZvKYlo2C")
+
+// validateDuration checks durations are in Go's format, e.g. 500ms or 5s.
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %q is not a duration, e.g. 500ms or 5s", k, value)}
+	}
+	if duration < 0 {
+		return nil, []error{fmt.Errorf("%s: %q can't be negative", k, value)}
+	}
+	return nil, nil
+}
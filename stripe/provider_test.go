@@ -0,0 +1,94 @@
+package stripe
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+// The acceptance tests run offline, against testAccStripeMock, so they are
+// run as part of the regular test suite through resource.UnitTest.
+var testAccStripeMock *stripeMock
+
+var testAccProviders map[string]terraform.ResourceProvider
+var testAccProvider *schema.Provider
+
+func init() {
+	testAccProvider = Provider().(*schema.Provider)
+	testAccProviders = map[string]terraform.ResourceProvider{
+		"stripe": testAccProvider,
+	}
+}
+
+func TestMain(m *testing.M) {
+	testAccStripeMock = newStripeMock()
+
+	os.Setenv("STRIPE_API_TOKEN", "sk_test_mock")
+	os.Setenv("STRIPE_API_BASE", testAccStripeMock.URL())
+	os.Setenv("STRIPE_UPLOADS_BASE", testAccStripeMock.URL())
+	os.Setenv("STRIPE_CONNECT_BASE", testAccStripeMock.URL())
+	// Archive the objects Stripe doesn't allow deleting, so that test cases
+	// can be torn down.
+	os.Setenv("STRIPE_ON_DESTROY", "archive")
+	stripe.DefaultLeveledLogger = &stripe.LeveledLogger{Level: stripe.LevelNull}
+
+	code := m.Run()
+	testAccStripeMock.Close()
+	os.Exit(code)
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvider_impl(t *testing.T) {
+	var _ terraform.ResourceProvider = Provider()
+}
+
+func TestProvider_invalidAPIBase(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "stripe" {
+  api_base = "ftp://localhost:12111/"
+}
+
+resource "stripe_product" "test" {
+  name = "Unreachable"
+  type = "service"
+}
+`,
+				ExpectError: regexp.MustCompile(`must be an http\(s\) URL`),
+			},
+		},
+	})
+}
+
+func TestProvider_invalidRetryBackoff(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, map[string]interface{}{
+		"retry_min_backoff": "10s",
+		"retry_max_backoff": "1s",
+	})
+
+	_, err := providerConfigure(d)
+	if err == nil || !strings.Contains(err.Error(), "retry_min_backoff (10s) can't be greater than retry_max_backoff (1s)") {
+		t.Fatalf("expected an invalid backoff error, got %v", err)
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	if v := os.Getenv("STRIPE_API_TOKEN"); v == "" {
+		t.Fatal("STRIPE_API_TOKEN must be set for acceptance tests")
+	}
+}
@@ -7,8 +7,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	stripe "github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripeCoupon() *schema.Resource {
@@ -20,6 +20,7 @@ func resourceStripeCoupon() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceStripeCouponValidate,
 
 		Schema: map[string]*schema.Schema{
 			"code": &schema.Schema{
@@ -36,6 +37,22 @@ func resourceStripeCoupon() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"currency_options": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"currency": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"amount_off": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
 			"duration": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true, // forever | once | repeating
@@ -69,9 +86,27 @@ func resourceStripeCoupon() *schema.Resource {
 				ForceNew: true,
 			},
 			"redeem_by": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+			},
+			"applies_to": &schema.Schema{
+				Type:     schema.TypeList,
 				Optional: true,
 				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"products": &schema.Schema{
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
 			},
 			// Computed
 			"valid": &schema.Schema{
@@ -90,36 +125,32 @@ func resourceStripeCoupon() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
 		},
 	}
 }
 
 func resourceStripeCouponCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
 	couponID := d.Get("code").(string)
 	params := &stripe.CouponParams{
 		ID: stripe.String(couponID),
 	}
 
 	couponDuration := d.Get("duration").(string)
-	validDurations := map[string]bool{
-		"repeating": true,
-		"once":      true,
-		"forever":   true,
-	}
-	if !(validDurations)[couponDuration] {
-		formattedKeys := "( " + strings.Join(getMapKeys(validDurations), " | ") + " )"
-		return fmt.Errorf("\"%s\" is not a valid value for \"duration\", expected one of %s", couponDuration, formattedKeys)
-	}
 
 	if name, ok := d.GetOk("name"); ok {
 		params.Name = stripe.String(name.(string))
 	}
 
 	if durationInMonths, ok := d.GetOk("duration_in_months"); ok {
-		if couponDuration != "repeating" {
-			return fmt.Errorf("can't set duration in months if event is not repeating")
-		}
 		params.DurationInMonths = stripe.Int64(int64(durationInMonths.(int)))
 	}
 
@@ -140,29 +171,45 @@ func resourceStripeCouponCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if currency, ok := d.GetOk("currency"); ok {
-		if &params.AmountOff == nil {
-			return fmt.Errorf("can't set currency when using percent off")
-		}
 		params.Currency = stripe.String(currency.(string))
 	}
 
-	if redeemByStr, ok := d.GetOk("redeem_by"); ok {
-		redeemByTime, err := time.Parse(time.RFC3339, redeemByStr.(string))
-
+	if redeemBy, ok := d.GetOk("redeem_by"); ok {
+		timestamp, err := expandTimestamp(redeemBy.(string))
 		if err != nil {
-			return fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", redeemByStr)
+			return fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", redeemBy)
 		}
+		params.RedeemBy = stripe.Int64(timestamp)
+	}
 
-		params.RedeemBy = stripe.Int64(redeemByTime.Unix())
+	if appliesTo, ok := d.GetOk("applies_to"); ok {
+		products := appliesTo.([]interface{})[0].(map[string]interface{})["products"].(*schema.Set)
+		params.AppliesTo = &stripe.CouponAppliesToParams{
+			Products: expandStringSlice(products.List()),
+		}
+	}
+
+	if currencyOptions, ok := d.GetOk("currency_options"); ok {
+		params.CurrencyOptions = expandCouponCurrencyOptions(currencyOptions.(*schema.Set).List())
 	}
 
 	params.Metadata = expandMetadata(d)
 
-	coupon, err := client.Coupons.New(params)
+	setStripeAccount(params, account)
+	var coupon *stripe.Coupon
+	err := createIdempotently(client, "coupons", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		coupon, err = client.Coupons.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return coupon.ID, coupon.LastResponse, nil
+	})
 
 	if err == nil {
 		log.Printf("[INFO] Create coupon: %s (%s)", coupon.Name, coupon.ID)
-		d.SetId(coupon.ID)
+		d.SetId(stripeResourceID(account, coupon.ID))
+		d.Set("stripe_account", account)
 		d.Set("valid", coupon.Valid)
 		d.Set("created", coupon.Created)
 		d.Set("times_redeemed", coupon.TimesRedeemed)
@@ -173,34 +220,146 @@ func resourceStripeCouponCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceStripeCouponRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	coupon, err := client.Coupons.Get(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.CouponParams{}
+	params.AddExpand("applies_to") // only returned when expanded
+	params.AddExpand("currency_options")
+	setStripeAccount(params, account)
+	coupon, err := client.Coupons.Get(id, params)
 
 	if err != nil {
 		d.SetId("")
 	} else {
-		d.Set("code", d.Id())
-		d.Set("amount_off", coupon.AmountOff)
-		d.Set("currency", coupon.Currency)
-		d.Set("duration", coupon.Duration)
-		d.Set("duration_in_months", coupon.DurationInMonths)
-		d.Set("livemode", coupon.Livemode)
-		d.Set("max_redemptions", coupon.MaxRedemptions)
-		d.Set("metadata", coupon.Metadata)
-		d.Set("name", coupon.Name)
-		d.Set("percent_off", coupon.PercentOff)
-		d.Set("redeem_by", coupon.RedeemBy)
-		d.Set("times_redeemed", coupon.TimesRedeemed)
-		d.Set("valid", coupon.Valid)
-		d.Set("created", coupon.Valid)
+		d.Set("stripe_account", account)
+		flattenCoupon(d, coupon)
 	}
 
 	return err
 }
 
+func flattenCoupon(d *schema.ResourceData, coupon *stripe.Coupon) {
+	d.Set("code", coupon.ID)
+	d.Set("amount_off", coupon.AmountOff)
+	d.Set("currency", coupon.Currency)
+	d.Set("currency_options", flattenCouponCurrencyOptions(coupon))
+	d.Set("duration", coupon.Duration)
+	d.Set("duration_in_months", coupon.DurationInMonths)
+	d.Set("livemode", coupon.Livemode)
+	d.Set("max_redemptions", coupon.MaxRedemptions)
+	d.Set("metadata", coupon.Metadata)
+	d.Set("name", coupon.Name)
+	d.Set("percent_off", coupon.PercentOff)
+	d.Set("redeem_by", flattenTimestamp(coupon.RedeemBy))
+	d.Set("applies_to", flattenCouponAppliesTo(coupon.AppliesTo))
+	d.Set("times_redeemed", coupon.TimesRedeemed)
+	d.Set("valid", coupon.Valid)
+	d.Set("created", coupon.Created)
+}
+
+var validCouponDurations = map[string]bool{
+	"repeating": true,
+	"once":      true,
+	"forever":   true,
+}
+
+// resourceStripeCouponValidate checks the coupon's arguments against each
+// other, so that invalid coupons are rejected at plan time rather than by
+// Stripe.
+func resourceStripeCouponValidate(d *schema.ResourceDiff, meta interface{}) error {
+	duration := d.Get("duration").(string)
+	if d.NewValueKnown("duration") && !validCouponDurations[duration] {
+		formattedKeys := "( " + strings.Join(getMapKeys(validCouponDurations), " | ") + " )"
+		return fmt.Errorf("\"%s\" is not a valid value for \"duration\", expected one of %s", duration, formattedKeys)
+	}
+
+	_, amountOff := d.GetOk("amount_off")
+	_, percentOff := d.GetOk("percent_off")
+	_, currency := d.GetOk("currency")
+	_, durationInMonths := d.GetOk("duration_in_months")
+	_, currencyOptions := d.GetOk("currency_options")
+
+	if d.NewValueKnown("amount_off") && d.NewValueKnown("percent_off") && amountOff == percentOff {
+		return fmt.Errorf("exactly one of amount_off or percent_off must be set")
+	}
+
+	if d.NewValueKnown("currency") {
+		if amountOff && !currency {
+			return fmt.Errorf("currency is required when using amount off")
+		}
+		if percentOff && currency {
+			return fmt.Errorf("can't set currency when using percent off")
+		}
+	}
+
+	if percentOff && currencyOptions {
+		return fmt.Errorf("can't set currency options when using percent off")
+	}
+
+	if d.NewValueKnown("duration") && d.NewValueKnown("duration_in_months") {
+		if durationInMonths && duration != "repeating" {
+			return fmt.Errorf("can't set duration in months if event is not repeating")
+		}
+		if !durationInMonths && duration == "repeating" {
+			return fmt.Errorf("duration in months is required when the duration is repeating")
+		}
+	}
+
+	// Coupons past their redeem by date remain in the state, only new dates
+	// are checked.
+	if redeemBy, ok := d.GetOk("redeem_by"); ok && (d.Id() == "" || d.HasChange("redeem_by")) {
+		timestamp, err := expandTimestamp(redeemBy.(string))
+		if err == nil && timestamp <= time.Now().Unix() {
+			return fmt.Errorf("redeem_by must be in the future, got %s", redeemBy)
+		}
+	}
+
+	return nil
+}
+
+func flattenCouponAppliesTo(appliesTo *stripe.CouponAppliesTo) []interface{} {
+	if appliesTo == nil || len(appliesTo.Products) == 0 {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"products": appliesTo.Products,
+		},
+	}
+}
+
+func expandCouponCurrencyOptions(in []interface{}) map[string]*stripe.CouponCurrencyOptionsParams {
+	out := make(map[string]*stripe.CouponCurrencyOptionsParams, len(in))
+	for _, v := range in {
+		option := v.(map[string]interface{})
+		out[option["currency"].(string)] = &stripe.CouponCurrencyOptionsParams{
+			AmountOff: stripe.Int64(int64(option["amount_off"].(int))),
+		}
+	}
+	return out
+}
+
+// flattenCouponCurrencyOptions flattens the coupon's additional currencies,
+// skipping its own currency that Stripe returns as well.
+func flattenCouponCurrencyOptions(coupon *stripe.Coupon) []interface{} {
+	out := []interface{}{}
+	for currency, option := range coupon.CurrencyOptions {
+		if currency == string(coupon.Currency) {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"currency":   currency,
+			"amount_off": option.AmountOff,
+		})
+	}
+	return out
+}
+
 func resourceStripeCouponUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
 	params := stripe.CouponParams{}
+	setStripeAccount(&params, account)
 
 	if d.HasChange("metadata") {
 		params.Metadata = expandMetadata(d)
@@ -210,7 +369,15 @@ func resourceStripeCouponUpdate(d *schema.ResourceData, m interface{}) error {
 		params.Name = stripe.String(d.Get("name").(string))
 	}
 
-	_, err := client.Coupons.Update(d.Id(), &params)
+	if d.HasChange("currency_options") {
+		old, new := d.GetChange("currency_options")
+		params.CurrencyOptions = expandCouponCurrencyOptions(new.(*schema.Set).List())
+		for _, currency := range removedCurrencies(old.(*schema.Set), new.(*schema.Set)) {
+			params.AddExtra("currency_options["+currency+"]", "")
+		}
+	}
+
+	_, err := client.Coupons.Update(id, &params)
 
 	if err != nil {
 		return err
@@ -220,8 +387,11 @@ func resourceStripeCouponUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceStripeCouponDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	_, err := client.Coupons.Del(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.CouponParams{}
+	setStripeAccount(params, account)
+	_, err := client.Coupons.Del(id, params)
 
 	if err == nil {
 		d.SetId("")
@@ -0,0 +1,346 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeCoupon_basic(t *testing.T) {
+	var coupon stripe.Coupon
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeCouponDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCouponConfig("King Sales Event"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCouponExists("stripe_coupon.test", &coupon),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "id", "MLK_DAY"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "code", "MLK_DAY"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "name", "King Sales Event"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "amount_off", "4200"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "currency", "usd"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "duration", "once"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "max_redemptions", "1024"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "metadata.sales", "yes"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "valid", "true"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "times_redeemed", "0"),
+					testAccCheckStripeCouponCreated("stripe_coupon.test", &coupon),
+				),
+			},
+			{
+				Config: testAccStripeCouponConfig("Queen Sales Event"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCouponExists("stripe_coupon.test", &coupon),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "name", "Queen Sales Event"),
+					testAccCheckStripeCouponCreated("stripe_coupon.test", &coupon),
+				),
+			},
+			{
+				ResourceName:      "stripe_coupon.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripeCoupon_percentOffRepeating(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeCouponDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_coupon" "test" {
+  code               = "SPRING25"
+  duration           = "repeating"
+  duration_in_months = 3
+  percent_off        = 25
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_coupon.test", "percent_off", "25"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "duration", "repeating"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "duration_in_months", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripeCoupon_currencyOptions(t *testing.T) {
+	var coupon stripe.Coupon
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeCouponDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCouponConfigCurrencyOptions(900),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCouponExists("stripe_coupon.test", &coupon),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "currency_options.#", "2"),
+					testAccCheckStripeCouponCurrencyOptions(&coupon, map[string]int64{"usd": 1000, "eur": 900, "jpy": 1500}),
+				),
+			},
+			{
+				ResourceName:      "stripe_coupon.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccStripeCouponConfigCurrencyOptions(950),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCouponCurrencyOptions(&coupon, map[string]int64{"usd": 1000, "eur": 950, "jpy": 1500}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripeCoupon_appliesTo(t *testing.T) {
+	redeemBy := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeCouponDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "stripe_product" "basic" {
+  name = "Basic"
+}
+
+resource "stripe_product" "premium" {
+  name = "Premium"
+}
+
+resource "stripe_coupon" "test" {
+  code        = "PLANS20"
+  duration    = "forever"
+  percent_off = 20
+  redeem_by   = "%s"
+
+  applies_to {
+    products = [stripe_product.basic.id, stripe_product.premium.id]
+  }
+}
+`, redeemBy.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_coupon.test", "applies_to.0.products.#", "2"),
+					resource.TestCheckResourceAttr("stripe_coupon.test", "redeem_by", redeemBy.Format(time.RFC3339)),
+				),
+			},
+			{
+				ResourceName:      "stripe_coupon.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripeCoupon_invalid(t *testing.T) {
+	for name, c := range map[string]struct {
+		arguments string
+		err       string
+	}{
+		"no discount": {
+			arguments: `duration = "once"`,
+			err:       "exactly one of amount_off or percent_off must be set",
+		},
+		"both discounts": {
+			arguments: `duration = "once"
+  amount_off  = 100
+  currency    = "usd"
+  percent_off = 10`,
+			err: "exactly one of amount_off or percent_off must be set",
+		},
+		"amount off without currency": {
+			arguments: `duration = "once"
+  amount_off = 100`,
+			err: "currency is required when using amount off",
+		},
+		"percent off with currency": {
+			arguments: `duration = "once"
+  percent_off = 10
+  currency    = "usd"`,
+			err: "can't set currency when using percent off",
+		},
+		"duration in months once": {
+			arguments: `duration = "once"
+  percent_off        = 10
+  duration_in_months = 3`,
+			err: "can't set duration in months if event is not repeating",
+		},
+		"repeating without duration in months": {
+			arguments: `duration = "repeating"
+  percent_off = 10`,
+			err: "duration in months is required",
+		},
+		"redeem by in the past": {
+			arguments: `duration = "once"
+  percent_off = 10
+  redeem_by   = "2020-01-01T00:00:00Z"`,
+			err: "redeem_by must be in the future",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+resource "stripe_coupon" "test" {
+  code = "INVALID"
+  %s
+}
+`, c.arguments),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(c.err)),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccStripeCoupon_invalidDuration(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_coupon" "test" {
+  code        = "NEVER"
+  duration    = "sometimes"
+  percent_off = 10
+}
+`,
+				ExpectError: regexp.MustCompile(`"sometimes" is not a valid value for "duration"`),
+			},
+		},
+	})
+}
+
+func testAccCheckStripeCouponExists(n string, coupon *stripe.Coupon) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.Coupons.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*coupon = *found
+		return nil
+	}
+}
+
+func testAccCheckStripeCouponCreated(n string, coupon *stripe.Coupon) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		return resource.TestCheckResourceAttr(n, "created", strconv.FormatInt(coupon.Created, 10))(s)
+	}
+}
+
+func testAccCheckStripeCouponCurrencyOptions(coupon *stripe.Coupon, expected map[string]int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		params := &stripe.CouponParams{}
+		params.AddExpand("currency_options")
+		found, err := client.Coupons.Get(coupon.ID, params)
+		if err != nil {
+			return err
+		}
+
+		if len(found.CurrencyOptions) != len(expected) {
+			return fmt.Errorf("expected %d currency options, got %d", len(expected), len(found.CurrencyOptions))
+		}
+		for currency, amountOff := range expected {
+			option, ok := found.CurrencyOptions[currency]
+			if !ok {
+				return fmt.Errorf("missing %s currency option", currency)
+			}
+			if option.AmountOff != amountOff {
+				return fmt.Errorf("expected %s amount off to be %d, got %d", currency, amountOff, option.AmountOff)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckStripeCouponDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_coupon" {
+			continue
+		}
+
+		if _, err := client.Coupons.Get(rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("coupon %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStripeCouponConfig(name string) string {
+	return fmt.Sprintf(`
+resource "stripe_coupon" "test" {
+  code     = "MLK_DAY"
+  name     = "%s"
+  duration = "once"
+
+  amount_off = 4200
+  currency   = "usd"
+
+  metadata = {
+    mlk   = "<3"
+    sales = "yes"
+  }
+
+  max_redemptions = 1024
+}
+`, name)
+}
+
+func testAccStripeCouponConfigCurrencyOptions(eurAmountOff int) string {
+	return fmt.Sprintf(`
+resource "stripe_coupon" "test" {
+  code       = "WORLDWIDE"
+  duration   = "once"
+  amount_off = 1000
+  currency   = "usd"
+
+  currency_options {
+    currency   = "eur"
+    amount_off = %d
+  }
+
+  currency_options {
+    currency   = "jpy"
+    amount_off = 1500
+  }
+}
+`, eurAmountOff)
+}
@@ -0,0 +1,684 @@
+package stripe
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeCustomer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStripeCustomerCreate,
+		Read:   resourceStripeCustomerRead,
+		Update: resourceStripeCustomerUpdate,
+		Delete: resourceStripeCustomerDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceStripeCustomerImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"email": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 512),
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"phone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"address": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     addressResource(),
+			},
+			"shipping": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"phone": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"address": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem:     addressResource(),
+						},
+					},
+				},
+			},
+			"preferred_locales": &schema.Schema{
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"tax_exempt": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validation.StringInSlice([]string{"none", "exempt", "reverse"}, false),
+			},
+			"invoice_settings": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"custom_field": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 4,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringLenBetween(1, 30),
+									},
+									"value": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringLenBetween(1, 30),
+									},
+								},
+							},
+						},
+						"default_payment_method": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"footer": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"rendering_options": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"amount_tax_display": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"exclude_tax", "include_inclusive_tax"}, false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"tax_id": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      resourceStripeCustomerTaxIDHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						// Computed
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"country": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"verification_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// Computed
+			"balance": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"currency": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"delinquent": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
+		},
+	}
+}
+
+func addressResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"line1": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"line2": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"city": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"postal_code": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"country": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Tax IDs can't be updated, they are identified by their type and value.
+func resourceStripeCustomerTaxIDHash(v interface{}) int {
+	taxID := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s-%s", taxID["type"], taxID["value"]))
+}
+
+func resourceStripeCustomerCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.CustomerParams{
+		TaxExempt: stripe.String(d.Get("tax_exempt").(string)),
+	}
+
+	if email, ok := d.GetOk("email"); ok {
+		params.Email = stripe.String(email.(string))
+	}
+
+	if name, ok := d.GetOk("name"); ok {
+		params.Name = stripe.String(name.(string))
+	}
+
+	if description, ok := d.GetOk("description"); ok {
+		params.Description = stripe.String(description.(string))
+	}
+
+	if phone, ok := d.GetOk("phone"); ok {
+		params.Phone = stripe.String(phone.(string))
+	}
+
+	if address, ok := d.GetOk("address"); ok {
+		params.Address = expandAddress(address.([]interface{}))
+	}
+
+	if shipping, ok := d.GetOk("shipping"); ok {
+		params.Shipping = expandCustomerShipping(shipping.([]interface{}))
+	}
+
+	if _, ok := d.GetOk("preferred_locales"); ok {
+		params.PreferredLocales = expandStringList(d, "preferred_locales")
+	}
+
+	if invoiceSettings, ok := d.GetOk("invoice_settings"); ok {
+		params.InvoiceSettings = expandCustomerInvoiceSettings(invoiceSettings.([]interface{}))
+	}
+
+	params.Metadata = expandMetadata(d)
+
+	setStripeAccount(params, account)
+	var customer *stripe.Customer
+	err := createIdempotently(client, "customers", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		customer, err = client.Customers.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return customer.ID, customer.LastResponse, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Create customer: %s (%s)", customer.Email, customer.ID)
+	d.SetId(stripeResourceID(account, customer.ID))
+
+	if taxIDs, ok := d.GetOk("tax_id"); ok {
+		err = resourceStripeCustomerUpdateTaxIDs(client, account, customer.ID, &schema.Set{F: resourceStripeCustomerTaxIDHash}, taxIDs.(*schema.Set))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceStripeCustomerRead(d, m)
+}
+
+func expandAddress(in []interface{}) *stripe.AddressParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	address := in[0].(map[string]interface{})
+	return &stripe.AddressParams{
+		Line1:      stripe.String(address["line1"].(string)),
+		Line2:      stripe.String(address["line2"].(string)),
+		City:       stripe.String(address["city"].(string)),
+		State:      stripe.String(address["state"].(string)),
+		PostalCode: stripe.String(address["postal_code"].(string)),
+		Country:    stripe.String(address["country"].(string)),
+	}
+}
+
+func flattenAddress(address stripe.Address) []interface{} {
+	if address == (stripe.Address{}) {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"line1":       address.Line1,
+			"line2":       address.Line2,
+			"city":        address.City,
+			"state":       address.State,
+			"postal_code": address.PostalCode,
+			"country":     address.Country,
+		},
+	}
+}
+
+func expandCustomerShipping(in []interface{}) *stripe.CustomerShippingDetailsParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	shipping := in[0].(map[string]interface{})
+	return &stripe.CustomerShippingDetailsParams{
+		Name:    stripe.String(shipping["name"].(string)),
+		Phone:   stripe.String(shipping["phone"].(string)),
+		Address: expandAddress(shipping["address"].([]interface{})),
+	}
+}
+
+func flattenCustomerShipping(shipping *stripe.CustomerShippingDetails) []interface{} {
+	if shipping == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":    shipping.Name,
+			"phone":   shipping.Phone,
+			"address": flattenAddress(shipping.Address),
+		},
+	}
+}
+
+func expandCustomerInvoiceSettings(in []interface{}) *stripe.CustomerInvoiceSettingsParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	invoiceSettings := in[0].(map[string]interface{})
+	params := &stripe.CustomerInvoiceSettingsParams{}
+
+	for _, v := range invoiceSettings["custom_field"].([]interface{}) {
+		customField := v.(map[string]interface{})
+		params.CustomFields = append(params.CustomFields, &stripe.CustomerInvoiceCustomFieldParams{
+			Name:  stripe.String(customField["name"].(string)),
+			Value: stripe.String(customField["value"].(string)),
+		})
+	}
+
+	if defaultPaymentMethod := invoiceSettings["default_payment_method"].(string); defaultPaymentMethod != "" {
+		params.DefaultPaymentMethod = stripe.String(defaultPaymentMethod)
+	}
+
+	if footer := invoiceSettings["footer"].(string); footer != "" {
+		params.Footer = stripe.String(footer)
+	}
+
+	if renderingOptions := invoiceSettings["rendering_options"].([]interface{}); len(renderingOptions) > 0 && renderingOptions[0] != nil {
+		params.RenderingOptions = &stripe.CustomerInvoiceSettingsRenderingOptionsParams{
+			AmountTaxDisplay: stripe.String(renderingOptions[0].(map[string]interface{})["amount_tax_display"].(string)),
+		}
+	}
+
+	return params
+}
+
+func flattenCustomerInvoiceSettings(invoiceSettings *stripe.CustomerInvoiceSettings) []interface{} {
+	if invoiceSettings == nil {
+		return nil
+	}
+
+	customFields := make([]interface{}, len(invoiceSettings.CustomFields))
+	for i, customField := range invoiceSettings.CustomFields {
+		customFields[i] = map[string]interface{}{
+			"name":  stripe.StringValue(customField.Name),
+			"value": stripe.StringValue(customField.Value),
+		}
+	}
+
+	var defaultPaymentMethod string
+	if invoiceSettings.DefaultPaymentMethod != nil {
+		defaultPaymentMethod = invoiceSettings.DefaultPaymentMethod.ID
+	}
+
+	var renderingOptions []interface{}
+	if invoiceSettings.RenderingOptions != nil && invoiceSettings.RenderingOptions.AmountTaxDisplay != "" {
+		renderingOptions = []interface{}{
+			map[string]interface{}{
+				"amount_tax_display": invoiceSettings.RenderingOptions.AmountTaxDisplay,
+			},
+		}
+	}
+
+	// Stripe always returns invoice settings; only keep them when some are
+	// set so that customers without them don't show a diff.
+	if len(customFields) == 0 && defaultPaymentMethod == "" && invoiceSettings.Footer == "" && len(renderingOptions) == 0 {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"custom_field":           customFields,
+			"default_payment_method": defaultPaymentMethod,
+			"footer":                 invoiceSettings.Footer,
+			"rendering_options":      renderingOptions,
+		},
+	}
+}
+
+func resourceStripeCustomerRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.CustomerParams{}
+	setStripeAccount(params, account)
+	customer, err := client.Customers.Get(id, params)
+
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	if customer.Deleted {
+		log.Printf("[WARN] Customer %s was deleted, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	taxIDs, err := resourceStripeCustomerListTaxIDs(client, account, id)
+	if err != nil {
+		return err
+	}
+
+	d.Set("stripe_account", account)
+	d.Set("email", customer.Email)
+	d.Set("name", customer.Name)
+	d.Set("description", customer.Description)
+	d.Set("phone", customer.Phone)
+	d.Set("address", flattenAddress(customer.Address))
+	d.Set("shipping", flattenCustomerShipping(customer.Shipping))
+	d.Set("preferred_locales", customer.PreferredLocales)
+	d.Set("tax_exempt", customer.TaxExempt)
+	d.Set("invoice_settings", flattenCustomerInvoiceSettings(customer.InvoiceSettings))
+	d.Set("metadata", customer.Metadata)
+	d.Set("tax_id", flattenCustomerTaxIDs(taxIDs))
+	d.Set("balance", customer.Balance)
+	d.Set("currency", customer.Currency)
+	d.Set("delinquent", customer.Delinquent)
+	d.Set("created", customer.Created)
+	d.Set("livemode", customer.Livemode)
+
+	return nil
+}
+
+func resourceStripeCustomerListTaxIDs(client *Client, account, customerID string) ([]*stripe.TaxID, error) {
+	params := &stripe.TaxIDListParams{
+		Customer: stripe.String(customerID),
+	}
+	setStripeAccount(params, account)
+
+	var taxIDs []*stripe.TaxID
+	i := client.TaxIDs.List(params)
+	for i.Next() {
+		taxIDs = append(taxIDs, i.TaxID())
+	}
+
+	return taxIDs, i.Err()
+}
+
+func flattenCustomerTaxIDs(taxIDs []*stripe.TaxID) *schema.Set {
+	out := &schema.Set{F: resourceStripeCustomerTaxIDHash}
+	for _, taxID := range taxIDs {
+		var verificationStatus string
+		if taxID.Verification != nil {
+			verificationStatus = string(taxID.Verification.Status)
+		}
+
+		out.Add(map[string]interface{}{
+			"type":                string(taxID.Type),
+			"value":               taxID.Value,
+			"id":                  taxID.ID,
+			"country":             taxID.Country,
+			"verification_status": verificationStatus,
+		})
+	}
+	return out
+}
+
+// resourceStripeCustomerUpdateTaxIDs deletes the tax IDs that are no longer
+// configured and creates the new ones.
+func resourceStripeCustomerUpdateTaxIDs(client *Client, account, customerID string, old, new *schema.Set) error {
+	for _, v := range old.Difference(new).List() {
+		taxID := v.(map[string]interface{})
+		params := &stripe.TaxIDParams{
+			Customer: stripe.String(customerID),
+		}
+		setStripeAccount(params, account)
+		if _, err := client.TaxIDs.Del(taxID["id"].(string), params); err != nil {
+			return err
+		}
+		log.Printf("[INFO] Deleted tax ID %s of customer %s", taxID["id"], customerID)
+	}
+
+	for _, v := range new.Difference(old).List() {
+		taxID := v.(map[string]interface{})
+		params := &stripe.TaxIDParams{
+			Customer: stripe.String(customerID),
+			Type:     stripe.String(taxID["type"].(string)),
+			Value:    stripe.String(taxID["value"].(string)),
+		}
+		setStripeAccount(params, account)
+		created, err := client.TaxIDs.New(params)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Created tax ID %s of customer %s", created.ID, customerID)
+	}
+
+	return nil
+}
+
+func resourceStripeCustomerUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := stripe.CustomerParams{}
+	setStripeAccount(&params, account)
+
+	if d.HasChange("email") {
+		params.Email = stripe.String(d.Get("email").(string))
+	}
+
+	if d.HasChange("name") {
+		params.Name = stripe.String(d.Get("name").(string))
+	}
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("phone") {
+		params.Phone = stripe.String(d.Get("phone").(string))
+	}
+
+	// Empty strings unset blocks that were removed.
+	if d.HasChange("address") {
+		if params.Address = expandAddress(d.Get("address").([]interface{})); params.Address == nil {
+			params.AddExtra("address", "")
+		}
+	}
+
+	if d.HasChange("shipping") {
+		if params.Shipping = expandCustomerShipping(d.Get("shipping").([]interface{})); params.Shipping == nil {
+			params.AddExtra("shipping", "")
+		}
+	}
+
+	if d.HasChange("preferred_locales") {
+		if params.PreferredLocales = expandStringList(d, "preferred_locales"); len(params.PreferredLocales) == 0 {
+			params.AddExtra("preferred_locales", "")
+		}
+	}
+
+	if d.HasChange("tax_exempt") {
+		params.TaxExempt = stripe.String(d.Get("tax_exempt").(string))
+	}
+
+	if d.HasChange("invoice_settings") {
+		params.InvoiceSettings = expandCustomerInvoiceSettings(d.Get("invoice_settings").([]interface{}))
+		if params.InvoiceSettings == nil {
+			params.InvoiceSettings = &stripe.CustomerInvoiceSettingsParams{}
+		}
+		if params.InvoiceSettings.DefaultPaymentMethod == nil {
+			params.InvoiceSettings.DefaultPaymentMethod = stripe.String("")
+		}
+		if params.InvoiceSettings.Footer == nil {
+			params.InvoiceSettings.Footer = stripe.String("")
+		}
+		if len(params.InvoiceSettings.CustomFields) == 0 {
+			params.AddExtra("invoice_settings[custom_fields]", "")
+		}
+		if params.InvoiceSettings.RenderingOptions == nil {
+			params.AddExtra("invoice_settings[rendering_options]", "")
+		}
+	}
+
+	if d.HasChange("metadata") {
+		params.Metadata = expandMetadata(d)
+	}
+
+	_, err := client.Customers.Update(id, &params)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("tax_id") {
+		old, new := d.GetChange("tax_id")
+		err = resourceStripeCustomerUpdateTaxIDs(client, account, id, old.(*schema.Set), new.(*schema.Set))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceStripeCustomerRead(d, m)
+}
+
+func resourceStripeCustomerDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.CustomerParams{}
+	setStripeAccount(params, account)
+	_, err := client.Customers.Del(id, params)
+
+	if err == nil {
+		d.SetId("")
+	}
+
+	return err
+}
+
+// resourceStripeCustomerImport imports customers by ID, or by email address
+// when given `email:<address>`.
+func resourceStripeCustomerImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	account, id := resourceStripeID(d, m)
+	if !strings.HasPrefix(id, "email:") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	client := m.(*Client)
+	email := strings.TrimPrefix(id, "email:")
+	params := &stripe.CustomerListParams{
+		Email: stripe.String(email),
+	}
+	setStripeAccount(params, account)
+
+	var customers []*stripe.Customer
+	i := client.Customers.List(params)
+	for i.Next() {
+		customers = append(customers, i.Customer())
+	}
+
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(customers) != 1 {
+		return nil, fmt.Errorf("expected exactly one customer with email %q, found %d", email, len(customers))
+	}
+
+	d.SetId(stripeResourceID(account, customers[0].ID))
+	return []*schema.ResourceData{d}, nil
+}
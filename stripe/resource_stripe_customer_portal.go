@@ -1,13 +1,13 @@
 package stripe
 
 import (
-	"fmt"
+	"encoding/json"
 	"log"
+	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceCustomerPortal() *schema.Resource {
@@ -39,6 +39,7 @@ func resourceCustomerPortal() *schema.Resource {
 					},
 				},
 				Required: true,
+				MaxItems: 1,
 			},
 			"features": &schema.Schema{
 				Type: schema.TypeList,
@@ -63,6 +64,8 @@ func resourceCustomerPortal() *schema.Resource {
 								},
 							},
 							Optional: true,
+							Computed: true,
+							MaxItems: 1,
 						},
 						"invoice_history": &schema.Schema{
 							Type: schema.TypeList,
@@ -75,6 +78,8 @@ func resourceCustomerPortal() *schema.Resource {
 								},
 							},
 							Optional: true,
+							Computed: true,
+							MaxItems: 1,
 						},
 						"payment_method_update": &schema.Schema{
 							Type: schema.TypeList,
@@ -87,6 +92,8 @@ func resourceCustomerPortal() *schema.Resource {
 								},
 							},
 							Optional: true,
+							Computed: true,
+							MaxItems: 1,
 						},
 						"subscription_cancel": &schema.Schema{
 							Type: schema.TypeList,
@@ -111,6 +118,8 @@ func resourceCustomerPortal() *schema.Resource {
 											},
 										},
 										Optional: true,
+										Computed: true,
+										MaxItems: 1,
 									},
 									"enabled": &schema.Schema{
 										Type:     schema.TypeBool,
@@ -119,16 +128,20 @@ func resourceCustomerPortal() *schema.Resource {
 									"mode": &schema.Schema{
 										Type:         schema.TypeString,
 										Optional:     true,
+										Computed:     true,
 										ValidateFunc: validation.StringInSlice([]string{"immediately", "at_period_end"}, false),
 									},
 									"proration_behavior": &schema.Schema{
 										Type:         schema.TypeString,
 										Optional:     true,
+										Computed:     true,
 										ValidateFunc: validation.StringInSlice([]string{"none", "create_prorations"}, false),
 									},
 								},
 							},
 							Optional: true,
+							Computed: true,
+							MaxItems: 1,
 						},
 						"subscription_pause": &schema.Schema{
 							Type: schema.TypeList,
@@ -141,6 +154,8 @@ func resourceCustomerPortal() *schema.Resource {
 								},
 							},
 							Optional: true,
+							Computed: true,
+							MaxItems: 1,
 						},
 						"subscription_update": &schema.Schema{
 							Type: schema.TypeList,
@@ -161,6 +176,7 @@ func resourceCustomerPortal() *schema.Resource {
 									"proration_behavior": &schema.Schema{
 										Type:         schema.TypeString,
 										Optional:     true,
+										Computed:     true,
 										ValidateFunc: validation.StringInSlice([]string{"none", "create_prorations", "always_invoice"}, false),
 									},
 									"product": {
@@ -185,10 +201,13 @@ func resourceCustomerPortal() *schema.Resource {
 								},
 							},
 							Optional: true,
+							Computed: true,
+							MaxItems: 1,
 						},
 					},
 				},
 				Required: true,
+				MaxItems: 1,
 			},
 			"default_return_url": &schema.Schema{
 				Type:     schema.TypeString,
@@ -201,6 +220,54 @@ func resourceCustomerPortal() *schema.Resource {
 				},
 				Optional: true,
 			},
+			"login_page": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						// Computed
+						"url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"on_destroy": onDestroySchema(),
+			// Computed
+			"is_default": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"updated": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
 		},
 	}
 }
@@ -300,11 +367,11 @@ func expandFeatures(featuresI []interface{}) *stripe.BillingPortalConfigurationF
 					subscriptionCancel.Enabled = stripe.Bool(val.(bool))
 				}
 
-				if val, ok := p["mode"]; ok {
+				if val, ok := p["mode"]; ok && val != "" {
 					subscriptionCancel.Mode = stripe.String(val.(string))
 				}
 
-				if val, ok := p["proration_behavior"]; ok {
+				if val, ok := p["proration_behavior"]; ok && val != "" {
 					subscriptionCancel.ProrationBehavior = stripe.String(val.(string))
 				}
 			}
@@ -365,7 +432,7 @@ func expandFeatures(featuresI []interface{}) *stripe.BillingPortalConfigurationF
 					subscriptionUpdate.Products = productsParams
 				}
 
-				if val, ok := p["proration_behavior"]; ok {
+				if val, ok := p["proration_behavior"]; ok && val != "" {
 					subscriptionUpdate.ProrationBehavior = stripe.String(val.(string))
 				}
 			}
@@ -376,8 +443,10 @@ func expandFeatures(featuresI []interface{}) *stripe.BillingPortalConfigurationF
 }
 
 func resourceStripeCustomerPortalCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
 	params := &stripe.BillingPortalConfigurationParams{}
+	setStripeAccount(params, account)
 	if dru, ok := d.GetOk("default_return_url"); ok {
 		params.DefaultReturnURL = stripe.String(dru.(string))
 	}
@@ -390,42 +459,233 @@ func resourceStripeCustomerPortalCreate(d *schema.ResourceData, m interface{}) e
 		params.Features = expandFeatures(bp.([]interface{}))
 	}
 
+	if lp, ok := d.GetOk("login_page"); ok {
+		addLoginPage(&params.Params, lp.([]interface{}))
+	}
+
 	params.Metadata = expandMetadata(d)
-	portal, err := client.BillingPortalConfigurations.New(params)
-	if err == nil {
-		log.Printf("[INFO] Customer Portal: %s", portal.ID)
-		d.SetId(portal.ID)
+	var portal *stripe.BillingPortalConfiguration
+	err := createIdempotently(client, "billing_portal/configurations", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		portal, err = client.BillingPortalConfigurations.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return portal.ID, portal.LastResponse, nil
+	})
+	if err != nil {
+		return err
 	}
-	return err
+
+	log.Printf("[INFO] Customer Portal: %s", portal.ID)
+	d.SetId(stripeResourceID(account, portal.ID))
+
+	// Configurations can only be deactivated once created.
+	if !d.Get("active").(bool) {
+		params := &stripe.BillingPortalConfigurationParams{
+			Active: stripe.Bool(false),
+		}
+		setStripeAccount(params, account)
+		if _, err := client.BillingPortalConfigurations.Update(portal.ID, params); err != nil {
+			return err
+		}
+	}
+
+	return resourceStripeCustomerPortalRead(d, m)
 }
 
 func resourceStripeCustomerPortalRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	portal, err := client.BillingPortalConfigurations.Get(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.BillingPortalConfigurationParams{}
+	setStripeAccount(params, account)
+	portal, err := client.BillingPortalConfigurations.Get(id, params)
 
 	if err != nil {
 		d.SetId("")
-	} else {
-		d.Set("id", portal.ID)
-		d.Set("object", portal.Object)
-		d.Set("active", portal.Active)
-		d.Set("business_profile", portal.BusinessProfile)
-		d.Set("created", portal.Created)
-		d.Set("default_return_url", portal.DefaultReturnURL)
-		d.Set("features", portal.Features)
-		d.Set("is_default", portal.IsDefault)
-		d.Set("livemode", portal.Livemode)
-		d.Set("metadata", portal.Metadata)
-		d.Set("updated", portal.Updated)
+		return err
+	}
+
+	loginPage, err := flattenLoginPage(portal.LastResponse, len(d.Get("login_page").([]interface{})) > 0)
+	if err != nil {
+		return err
+	}
+
+	d.Set("stripe_account", account)
+	d.Set("active", portal.Active)
+	d.Set("business_profile", flattenBusinessProfile(portal.BusinessProfile))
+	d.Set("created", portal.Created)
+	d.Set("default_return_url", portal.DefaultReturnURL)
+	d.Set("features", flattenFeatures(portal.Features))
+	d.Set("is_default", portal.IsDefault)
+	d.Set("livemode", portal.Livemode)
+	d.Set("login_page", loginPage)
+	d.Set("metadata", portal.Metadata)
+	d.Set("updated", portal.Updated)
+
+	return nil
+}
+
+// The Stripe SDK doesn't know about login pages yet, they're sent as extra
+// parameters and read from the raw response.
+func addLoginPage(params *stripe.Params, loginPageI []interface{}) {
+	for _, v := range loginPageI {
+		loginPage := v.(map[string]interface{})
+		params.AddExtra("login_page[enabled]", strconv.FormatBool(loginPage["enabled"].(bool)))
+	}
+}
+
+// flattenLoginPage flattens the portal's login page, unless it's disabled and
+// the resource doesn't configure one.
+func flattenLoginPage(response *stripe.APIResponse, configured bool) ([]interface{}, error) {
+	if response == nil {
+		return nil, nil
+	}
+
+	raw := struct {
+		LoginPage *struct {
+			Enabled bool   `json:"enabled"`
+			URL     string `json:"url"`
+		} `json:"login_page"`
+	}{}
+	if err := json.Unmarshal(response.RawJSON, &raw); err != nil {
+		return nil, err
+	}
+
+	if raw.LoginPage == nil || (!raw.LoginPage.Enabled && !configured) {
+		return nil, nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"enabled": raw.LoginPage.Enabled,
+			"url":     raw.LoginPage.URL,
+		},
+	}, nil
+}
+
+func flattenBusinessProfile(businessProfile *stripe.BillingPortalConfigurationBusinessProfile) []interface{} {
+	if businessProfile == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"headline":             businessProfile.Headline,
+			"privacy_policy_url":   businessProfile.PrivacyPolicyURL,
+			"terms_of_service_url": businessProfile.TermsOfServiceURL,
+		},
 	}
-	return err
+}
+
+func flattenFeatures(features *stripe.BillingPortalConfigurationFeatures) []interface{} {
+	if features == nil {
+		return nil
+	}
+
+	out := map[string]interface{}{}
+
+	if cu := features.CustomerUpdate; cu != nil {
+		allowedUpdates := make([]interface{}, len(cu.AllowedUpdates))
+		for i, update := range cu.AllowedUpdates {
+			allowedUpdates[i] = string(update)
+		}
+		out["customer_update"] = []interface{}{
+			map[string]interface{}{
+				"allowed_updates": allowedUpdates,
+				"enabled":         cu.Enabled,
+			},
+		}
+	}
+
+	if ih := features.InvoiceHistory; ih != nil {
+		out["invoice_history"] = []interface{}{
+			map[string]interface{}{
+				"enabled": ih.Enabled,
+			},
+		}
+	}
+
+	if pmu := features.PaymentMethodUpdate; pmu != nil {
+		out["payment_method_update"] = []interface{}{
+			map[string]interface{}{
+				"enabled": pmu.Enabled,
+			},
+		}
+	}
+
+	if sc := features.SubscriptionCancel; sc != nil {
+		subscriptionCancel := map[string]interface{}{
+			"enabled":            sc.Enabled,
+			"mode":               string(sc.Mode),
+			"proration_behavior": string(sc.ProrationBehavior),
+		}
+		if scr := sc.CancellationReason; scr != nil {
+			options := make([]interface{}, len(scr.Options))
+			for i, option := range scr.Options {
+				options[i] = string(option)
+			}
+			subscriptionCancel["cancellation_reason"] = []interface{}{
+				map[string]interface{}{
+					"enabled": scr.Enabled,
+					"options": options,
+				},
+			}
+		}
+		out["subscription_cancel"] = []interface{}{subscriptionCancel}
+	}
+
+	if sp := features.SubscriptionPause; sp != nil {
+		out["subscription_pause"] = []interface{}{
+			map[string]interface{}{
+				"enabled": sp.Enabled,
+			},
+		}
+	}
+
+	if su := features.SubscriptionUpdate; su != nil {
+		defaultAllowedUpdates := make([]interface{}, len(su.DefaultAllowedUpdates))
+		for i, update := range su.DefaultAllowedUpdates {
+			defaultAllowedUpdates[i] = string(update)
+		}
+		products := make([]interface{}, len(su.Products))
+		for i, product := range su.Products {
+			products[i] = map[string]interface{}{
+				"id":     product.Product,
+				"prices": product.Prices,
+			}
+		}
+		out["subscription_update"] = []interface{}{
+			map[string]interface{}{
+				"default_allowed_updates": defaultAllowedUpdates,
+				"enabled":                 su.Enabled,
+				"proration_behavior":      string(su.ProrationBehavior),
+				"product":                 products,
+			},
+		}
+	}
+
+	return []interface{}{out}
 }
 
 func resourceStripeCustomerPortalUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
 	params := &stripe.BillingPortalConfigurationParams{}
+	setStripeAccount(params, account)
 	if d.HasChange("default_return_url") {
-		params.BusinessProfile.Headline = stripe.String(d.Get("default_return_url").(string))
+		params.DefaultReturnURL = stripe.String(d.Get("default_return_url").(string))
+	}
+
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(d.Get("active").(bool))
+	}
+
+	if d.HasChange("login_page") {
+		loginPage := d.Get("login_page").([]interface{})
+		if len(loginPage) == 0 {
+			// Removing the block disables the login page.
+			params.AddExtra("login_page[enabled]", "false")
+		}
+		addLoginPage(&params.Params, loginPage)
 	}
 
 	if d.HasChange("metadata") {
@@ -442,7 +702,7 @@ func resourceStripeCustomerPortalUpdate(d *schema.ResourceData, m interface{}) e
 		params.Features = expandFeatures(new.([]interface{}))
 	}
 
-	_, err := client.BillingPortalConfigurations.Update(d.Id(), params)
+	_, err := client.BillingPortalConfigurations.Update(id, params)
 	if err != nil {
 		return err
 	}
@@ -450,5 +710,21 @@ func resourceStripeCustomerPortalUpdate(d *schema.ResourceData, m interface{}) e
 }
 
 func resourceStripeCustomerPortalDelete(d *schema.ResourceData, m interface{}) error {
-	return fmt.Errorf("[WARNING] Stripe doesn't allow deleting customer portal via the API. Please remove it manually")
+	return resourceStripeArchivableDelete(d, m, "customer portal", func() error {
+		// The default configuration can't be deactivated, it's only
+		// dropped from the state.
+		if d.Get("is_default").(bool) {
+			log.Printf("[INFO] Customer portal %s is the default configuration and stays active", d.Id())
+			return nil
+		}
+
+		client := m.(*Client)
+		account, id := resourceStripeID(d, m)
+		params := &stripe.BillingPortalConfigurationParams{
+			Active: stripe.Bool(false),
+		}
+		setStripeAccount(params, account)
+		_, err := client.BillingPortalConfigurations.Update(id, params)
+		return err
+	})
 }
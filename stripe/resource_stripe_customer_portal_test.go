@@ -0,0 +1,414 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeCustomerPortal_basic(t *testing.T) {
+	var portal stripe.BillingPortalConfiguration
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeCustomerPortalDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCustomerPortalConfig("Headline"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCustomerPortalExists("stripe_customer_portal.test", &portal),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "business_profile.0.headline", "Headline"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "features.0.invoice_history.0.enabled", "true"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "default_return_url", "https://return.example"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "metadata.key", "val"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "features.0.customer_update.0.allowed_updates.#", "2"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "features.0.subscription_cancel.0.cancellation_reason.0.options.1", "other"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "features.0.subscription_cancel.0.mode", "at_period_end"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "features.0.subscription_update.0.product.#", "1"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "is_default", "false"),
+					resource.TestCheckResourceAttrSet("stripe_customer_portal.test", "created"),
+					resource.TestCheckResourceAttrSet("stripe_customer_portal.test", "updated"),
+					testAccCheckStripeCustomerPortalHeadline(&portal, "Headline"),
+				),
+			},
+			{
+				// Changes made outside of Terraform are detected and reverted.
+				PreConfig: func() {
+					testAccStripeCustomerPortalChangeHeadline(&portal, "Changed in the dashboard")
+				},
+				Config: testAccStripeCustomerPortalConfig("Headline"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCustomerPortalExists("stripe_customer_portal.test", &portal),
+					testAccCheckStripeCustomerPortalHeadline(&portal, "Headline"),
+				),
+			},
+			{
+				Config: testAccStripeCustomerPortalConfig("Updated headline"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCustomerPortalExists("stripe_customer_portal.test", &portal),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "business_profile.0.headline", "Updated headline"),
+					testAccCheckStripeCustomerPortalHeadline(&portal, "Updated headline"),
+				),
+			},
+			{
+				ResourceName:            "stripe_customer_portal.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"on_destroy"},
+			},
+		},
+	})
+}
+
+// TestAccStripeCustomerPortal_example round-trips the portal from main.tf.
+func TestAccStripeCustomerPortal_example(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCustomerPortalExampleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_customer_portal.customer_portal", "features.0.customer_update.0.allowed_updates.#", "5"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.customer_portal", "features.0.subscription_cancel.0.cancellation_reason.0.options.#", "8"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.customer_portal", "features.0.subscription_pause.0.enabled", "true"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.customer_portal", "features.0.subscription_update.0.default_allowed_updates.#", "3"),
+				),
+			},
+			{
+				ResourceName:            "stripe_customer_portal.customer_portal",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"on_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccStripeCustomerPortal_loginPage(t *testing.T) {
+	var before, after stripe.BillingPortalConfiguration
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeCustomerPortalDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCustomerPortalLoginPageConfig(true, true, "https://brand-a.example/account"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCustomerPortalExists("stripe_customer_portal.test", &before),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "login_page.0.enabled", "true"),
+					resource.TestMatchResourceAttr("stripe_customer_portal.test", "login_page.0.url", regexp.MustCompile(`^https://billing\.stripe\.com/p/login/`)),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "default_return_url", "https://brand-a.example/account"),
+				),
+			},
+			{
+				Config: testAccStripeCustomerPortalLoginPageConfig(false, false, "https://brand-b.example/account"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCustomerPortalExists("stripe_customer_portal.test", &after),
+					testAccCheckStripeCustomerPortalNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "login_page.0.enabled", "false"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "login_page.0.url", ""),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "active", "false"),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "default_return_url", "https://brand-b.example/account"),
+				),
+			},
+			{
+				Config: testAccStripeCustomerPortalLoginPageConfig(true, true, "https://brand-a.example/account"),
+			},
+			{
+				// Removing the block disables the login page, or the plan
+				// following the apply wouldn't be empty.
+				Config: testAccStripeCustomerPortalNoLoginPageConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCustomerPortalExists("stripe_customer_portal.test", &after),
+					testAccCheckStripeCustomerPortalNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr("stripe_customer_portal.test", "login_page.#", "0"),
+				),
+			},
+			{
+				ResourceName:            "stripe_customer_portal.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"on_destroy"},
+			},
+		},
+	})
+}
+
+func testAccCheckStripeCustomerPortalExists(n string, portal *stripe.BillingPortalConfiguration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.BillingPortalConfigurations.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*portal = *found
+		return nil
+	}
+}
+
+func testAccCheckStripeCustomerPortalNotRecreated(before, after *stripe.BillingPortalConfiguration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID != after.ID {
+			return fmt.Errorf("expected customer portal %s to be updated, it was replaced by %s", before.ID, after.ID)
+		}
+		return nil
+	}
+}
+
+// Customer portals can't be deleted, destroying them deactivates them.
+func testAccCheckStripeCustomerPortalDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_customer_portal" {
+			continue
+		}
+
+		portal, err := client.BillingPortalConfigurations.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if portal.Active {
+			return fmt.Errorf("customer portal %s should have been deactivated", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckStripeCustomerPortalHeadline(portal *stripe.BillingPortalConfiguration, headline string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if portal.BusinessProfile == nil || portal.BusinessProfile.Headline != headline {
+			return fmt.Errorf("expected headline %q, got %+v", headline, portal.BusinessProfile)
+		}
+		return nil
+	}
+}
+
+func testAccStripeCustomerPortalChangeHeadline(portal *stripe.BillingPortalConfiguration, headline string) {
+	client := testAccProvider.Meta().(*Client)
+	params := &stripe.BillingPortalConfigurationParams{
+		BusinessProfile: &stripe.BillingPortalConfigurationBusinessProfileParams{
+			Headline: stripe.String(headline),
+		},
+	}
+	if _, err := client.BillingPortalConfigurations.Update(portal.ID, params); err != nil {
+		panic(err)
+	}
+}
+
+func testAccStripeCustomerPortalConfig(headline string) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Portal"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1337
+
+  recurring {
+    interval = "month"
+  }
+}
+
+resource "stripe_customer_portal" "test" {
+  business_profile {
+    headline             = "%s"
+    terms_of_service_url = "https://terms-of-service-url.example"
+    privacy_policy_url   = "https://privacy-policy-url.example"
+  }
+
+  features {
+    customer_update {
+      allowed_updates = ["email", "address"]
+      enabled         = true
+    }
+
+    invoice_history {
+      enabled = true
+    }
+
+    payment_method_update {
+      enabled = true
+    }
+
+    subscription_cancel {
+      cancellation_reason {
+        enabled = true
+        options = ["too_expensive", "other"]
+      }
+      enabled            = true
+      mode               = "at_period_end"
+      proration_behavior = "none"
+    }
+
+    subscription_pause {
+      enabled = false
+    }
+
+    subscription_update {
+      default_allowed_updates = ["price", "quantity"]
+      enabled                 = true
+      proration_behavior      = "none"
+
+      product {
+        id     = stripe_product.test.id
+        prices = [stripe_price.test.id]
+      }
+    }
+  }
+
+  default_return_url = "https://return.example"
+
+  metadata = {
+    key = "val"
+  }
+}
+`, headline)
+}
+
+func testAccStripeCustomerPortalLoginPageConfig(loginPage, active bool, returnURL string) string {
+	return fmt.Sprintf(`
+resource "stripe_customer_portal" "test" {
+  business_profile {
+    headline             = "Brand"
+    terms_of_service_url = "https://terms-of-service-url.example"
+    privacy_policy_url   = "https://privacy-policy-url.example"
+  }
+
+  features {
+    invoice_history {
+      enabled = true
+    }
+  }
+
+  login_page {
+    enabled = %t
+  }
+
+  active             = %t
+  default_return_url = "%s"
+}
+`, loginPage, active, returnURL)
+}
+
+const testAccStripeCustomerPortalNoLoginPageConfig = `
+resource "stripe_customer_portal" "test" {
+  business_profile {
+    headline             = "Brand"
+    terms_of_service_url = "https://terms-of-service-url.example"
+    privacy_policy_url   = "https://privacy-policy-url.example"
+  }
+
+  features {
+    invoice_history {
+      enabled = true
+    }
+  }
+
+  default_return_url = "https://brand-a.example/account"
+}
+`
+
+const testAccStripeCustomerPortalExampleConfig = `
+resource "stripe_product" "my_product" {
+  name = "My Product"
+  type = "service"
+}
+
+resource "stripe_price" "my_price" {
+  active   = true
+  currency = "usd" # lowercase
+  metadata = {
+    blm = "always"
+  }
+  nickname    = "my price"
+  product     = stripe_product.my_product.id
+  unit_amount = 1337
+  recurring {
+    interval       = "month"
+    interval_count = 1
+    usage_type     = "licensed"
+  }
+  billing_scheme = "per_unit"
+}
+
+resource "stripe_customer_portal" "customer_portal" {
+
+  business_profile {
+    headline             = "Headline"
+    terms_of_service_url = "https://terms-of-service-url.example"
+    privacy_policy_url   = "https://privacy-policy-url.example"
+  }
+
+  features {
+
+    customer_update {
+      allowed_updates = ["email", "address", "shipping", "phone", "tax_id"]
+      enabled         = true
+    }
+
+    invoice_history {
+      enabled = true
+    }
+
+    payment_method_update {
+      enabled = true
+    }
+
+    subscription_cancel {
+      cancellation_reason {
+        enabled = true
+        options = ["too_expensive", "missing_features", "switched_service", "unused", "customer_service", "too_complex", "low_quality", "other"]
+      }
+      enabled            = true
+      mode               = "at_period_end"
+      proration_behavior = "none"
+
+    }
+
+    subscription_pause {
+      enabled = true
+    }
+
+    subscription_update {
+      default_allowed_updates = ["price", "quantity", "promotion_code"]
+      enabled                 = true
+      proration_behavior      = "none"
+
+      product {
+        id  = stripe_product.my_product.id
+        prices = [stripe_price.my_price.id]
+      }
+
+    }
+
+  }
+
+  metadata = {
+    key = "val"
+  }
+
+  default_return_url = "https://return.example"
+
+}
+`
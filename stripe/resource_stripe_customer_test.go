@@ -0,0 +1,241 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeCustomer_basic(t *testing.T) {
+	var customer stripe.Customer
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeCustomerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeCustomerConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCustomerExists("stripe_customer.test", &customer),
+					resource.TestCheckResourceAttr("stripe_customer.test", "email", "billing@acme.example"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "name", "ACME Corp"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "address.0.city", "Berlin"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "address.0.country", "DE"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "shipping.0.name", "ACME Warehouse"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "shipping.0.address.0.postal_code", "20095"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "preferred_locales.#", "2"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "preferred_locales.0", "de"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "tax_exempt", "reverse"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "invoice_settings.0.custom_field.#", "1"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "invoice_settings.0.custom_field.0.name", "PO"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "invoice_settings.0.footer", "Net 30"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "invoice_settings.0.rendering_options.0.amount_tax_display", "exclude_tax"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "metadata.segment", "enterprise"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "tax_id.#", "2"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "balance", "0"),
+					testAccCheckStripeCustomerTaxIDs(&customer, "eu_vat:DE123456789", "gb_vat:GB123456789"),
+				),
+			},
+			{
+				Config: testAccStripeCustomerConfigUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeCustomerExists("stripe_customer.test", &customer),
+					resource.TestCheckResourceAttr("stripe_customer.test", "name", "ACME Corporation"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "address.#", "1"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "address.0.line2", ""),
+					resource.TestCheckResourceAttr("stripe_customer.test", "shipping.#", "0"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "preferred_locales.#", "0"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "tax_exempt", "none"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "invoice_settings.0.custom_field.#", "0"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "invoice_settings.0.footer", "Net 60"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "invoice_settings.0.rendering_options.#", "0"),
+					resource.TestCheckResourceAttr("stripe_customer.test", "tax_id.#", "2"),
+					testAccCheckStripeCustomerTaxIDs(&customer, "eu_vat:DE123456789", "us_ein:12-3456789"),
+				),
+			},
+			{
+				ResourceName:      "stripe_customer.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "stripe_customer.test",
+				ImportState:       true,
+				ImportStateId:     "email:billing@acme.example",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripeCustomer_importEmailNotFound(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:        `resource "stripe_customer" "test" {}`,
+				ResourceName:  "stripe_customer.test",
+				ImportState:   true,
+				ImportStateId: "email:nobody@acme.example",
+				ExpectError:   regexp.MustCompile(`expected exactly one customer with email "nobody@acme.example", found 0`),
+			},
+		},
+	})
+}
+
+func testAccCheckStripeCustomerExists(n string, customer *stripe.Customer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.Customers.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*customer = *found
+		return nil
+	}
+}
+
+// testAccCheckStripeCustomerTaxIDs checks the customer's tax IDs, given as
+// "<type>:<value>", through the TaxID API.
+func testAccCheckStripeCustomerTaxIDs(customer *stripe.Customer, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		taxIDs, err := resourceStripeCustomerListTaxIDs(client, "", customer.ID)
+		if err != nil {
+			return err
+		}
+
+		var actual []string
+		for _, taxID := range taxIDs {
+			actual = append(actual, fmt.Sprintf("%s:%s", taxID.Type, taxID.Value))
+		}
+		sort.Strings(actual)
+
+		if strings.Join(actual, ",") != strings.Join(expected, ",") {
+			return fmt.Errorf("expected customer %s to have tax IDs %v, got %v", customer.ID, expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccCheckStripeCustomerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_customer" {
+			continue
+		}
+
+		customer, err := client.Customers.Get(rs.Primary.ID, nil)
+		if err == nil && !customer.Deleted {
+			return fmt.Errorf("customer %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccStripeCustomerConfig = `
+resource "stripe_customer" "test" {
+  email             = "billing@acme.example"
+  name              = "ACME Corp"
+  description       = "Enterprise agreement 2022"
+  phone             = "+49 30 1234567"
+  preferred_locales = ["de", "en"]
+  tax_exempt        = "reverse"
+
+  address {
+    line1       = "Friedrichstraße 1"
+    line2       = "3. OG"
+    city        = "Berlin"
+    postal_code = "10117"
+    country     = "DE"
+  }
+
+  shipping {
+    name = "ACME Warehouse"
+
+    address {
+      line1       = "Hafenstraße 2"
+      city        = "Hamburg"
+      postal_code = "20095"
+      country     = "DE"
+    }
+  }
+
+  invoice_settings {
+    footer = "Net 30"
+
+    custom_field {
+      name  = "PO"
+      value = "4500012345"
+    }
+
+    rendering_options {
+      amount_tax_display = "exclude_tax"
+    }
+  }
+
+  tax_id {
+    type  = "eu_vat"
+    value = "DE123456789"
+  }
+
+  tax_id {
+    type  = "gb_vat"
+    value = "GB123456789"
+  }
+
+  metadata = {
+    segment = "enterprise"
+  }
+}
+`
+
+const testAccStripeCustomerConfigUpdated = `
+resource "stripe_customer" "test" {
+  email       = "billing@acme.example"
+  name        = "ACME Corporation"
+  description = "Enterprise agreement 2022"
+  phone       = "+49 30 1234567"
+
+  address {
+    line1       = "Friedrichstraße 1"
+    city        = "Berlin"
+    postal_code = "10117"
+    country     = "DE"
+  }
+
+  invoice_settings {
+    footer = "Net 60"
+  }
+
+  tax_id {
+    type  = "eu_vat"
+    value = "DE123456789"
+  }
+
+  tax_id {
+    type  = "us_ein"
+    value = "12-3456789"
+  }
+
+  metadata = {
+    segment = "enterprise"
+  }
+}
+`
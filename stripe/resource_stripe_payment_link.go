@@ -0,0 +1,720 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripePaymentLink() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStripePaymentLinkCreate,
+		Read:   resourceStripePaymentLinkRead,
+		Update: resourceStripePaymentLinkUpdate,
+		Delete: resourceStripePaymentLinkDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceStripePaymentLinkForceNewPrices,
+
+		Schema: map[string]*schema.Schema{
+			"line_item": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 20,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"price": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true, // can't be updated, see resourceStripePaymentLinkForceNewPrices
+						},
+						"quantity": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						// Customers can change the quantity when the block is set.
+						"adjustable_quantity": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"minimum": &schema.Schema{
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true, // 0 by default
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"maximum": &schema.Schema{
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Computed:     true, // 99 by default
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+						// Computed
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"after_completion": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true, // a hosted confirmation page by default
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"redirect", "hosted_confirmation"}, false),
+						},
+						"redirect": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"url": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"hosted_confirmation": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"custom_message": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"allow_promotion_codes": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"automatic_tax": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+			"billing_address_collection": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "auto",
+				ValidateFunc: validation.StringInSlice([]string{"auto", "required"}, false),
+			},
+			"custom_field": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"text", "numeric", "dropdown"}, false),
+						},
+						"optional": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"dropdown_option": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"label": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"value": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"phone_number_collection": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+			"shipping_address_collection": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allowed_countries": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"subscription_data": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"trial_period_days": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"livemode": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"on_destroy": onDestroySchema(),
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
+		},
+	}
+}
+
+func resourceStripePaymentLinkCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.PaymentLinkParams{
+		LineItems:                expandPaymentLinkLineItems(d.Get("line_item").([]interface{}), false),
+		AllowPromotionCodes:      stripe.Bool(d.Get("allow_promotion_codes").(bool)),
+		BillingAddressCollection: stripe.String(d.Get("billing_address_collection").(string)),
+	}
+
+	if afterCompletion, ok := d.GetOk("after_completion"); ok {
+		params.AfterCompletion = expandPaymentLinkAfterCompletion(afterCompletion.([]interface{}))
+	}
+
+	if automaticTax, ok := d.GetOk("automatic_tax"); ok {
+		params.AutomaticTax = &stripe.PaymentLinkAutomaticTaxParams{
+			Enabled: stripe.Bool(expandEnabled(automaticTax.([]interface{}))),
+		}
+	}
+
+	if phoneNumberCollection, ok := d.GetOk("phone_number_collection"); ok {
+		params.PhoneNumberCollection = &stripe.PaymentLinkPhoneNumberCollectionParams{
+			Enabled: stripe.Bool(expandEnabled(phoneNumberCollection.([]interface{}))),
+		}
+	}
+
+	if shippingAddressCollection, ok := d.GetOk("shipping_address_collection"); ok {
+		params.ShippingAddressCollection = expandPaymentLinkShippingAddressCollection(shippingAddressCollection.([]interface{}))
+	}
+
+	if trialPeriodDays, ok := d.GetOk("subscription_data.0.trial_period_days"); ok {
+		params.SubscriptionData = &stripe.PaymentLinkSubscriptionDataParams{
+			TrialPeriodDays: stripe.Int64(int64(trialPeriodDays.(int))),
+		}
+	}
+
+	addPaymentLinkCustomFields(&params.Params, d.Get("custom_field").([]interface{}))
+
+	params.Metadata = expandMetadata(d)
+
+	setStripeAccount(params, account)
+	var link *stripe.PaymentLink
+	err := createIdempotently(client, "payment_links", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		link, err = client.PaymentLinks.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return link.ID, link.LastResponse, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Create payment link: %s (%s)", link.ID, link.URL)
+	d.SetId(stripeResourceID(account, link.ID))
+
+	// Payment links are always created active.
+	if !d.Get("active").(bool) {
+		params := &stripe.PaymentLinkParams{
+			Active: stripe.Bool(false),
+		}
+		setStripeAccount(params, account)
+		if _, err := client.PaymentLinks.Update(link.ID, params); err != nil {
+			return err
+		}
+	}
+
+	return resourceStripePaymentLinkRead(d, m)
+}
+
+// resourceStripePaymentLinkForceNewPrices replaces the payment link when the
+// price of one of its line items changes. Line items can be added or removed
+// in place, but only the quantities of existing ones can be updated.
+func resourceStripePaymentLinkForceNewPrices(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	old, new := d.GetChange("line_item")
+	oldItems, newItems := old.([]interface{}), new.([]interface{})
+	for i := 0; i < len(oldItems) && i < len(newItems); i++ {
+		key := fmt.Sprintf("line_item.%d.price", i)
+		oldPrice := oldItems[i].(map[string]interface{})["price"].(string)
+		newPrice := newItems[i].(map[string]interface{})["price"].(string)
+		if oldPrice != newPrice || !d.NewValueKnown(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandPaymentLinkLineItems expands the line items. When updating, only
+// the quantities of existing line items can change and they are referenced
+// by ID, new line items are sent with their price.
+func expandPaymentLinkLineItems(in []interface{}, update bool) []*stripe.PaymentLinkLineItemParams {
+	out := make([]*stripe.PaymentLinkLineItemParams, len(in))
+	for i, v := range in {
+		item := v.(map[string]interface{})
+		params := &stripe.PaymentLinkLineItemParams{
+			Quantity: stripe.Int64(int64(item["quantity"].(int))),
+		}
+		id, _ := item["id"].(string)
+		existing := update && id != ""
+		if existing {
+			params.ID = stripe.String(id)
+		} else {
+			params.Price = stripe.String(item["price"].(string))
+		}
+
+		if adjustableQuantity := item["adjustable_quantity"].([]interface{}); len(adjustableQuantity) > 0 {
+			params.AdjustableQuantity = &stripe.PaymentLinkLineItemAdjustableQuantityParams{
+				Enabled: stripe.Bool(true),
+			}
+			a, _ := adjustableQuantity[0].(map[string]interface{})
+			if minimum, _ := a["minimum"].(int); minimum > 0 {
+				params.AdjustableQuantity.Minimum = stripe.Int64(int64(minimum))
+			}
+			if maximum, _ := a["maximum"].(int); maximum > 0 {
+				params.AdjustableQuantity.Maximum = stripe.Int64(int64(maximum))
+			}
+		} else if existing {
+			params.AdjustableQuantity = &stripe.PaymentLinkLineItemAdjustableQuantityParams{
+				Enabled: stripe.Bool(false),
+			}
+		}
+
+		out[i] = params
+	}
+	return out
+}
+
+func expandPaymentLinkAfterCompletion(in []interface{}) *stripe.PaymentLinkAfterCompletionParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	afterCompletion := in[0].(map[string]interface{})
+	params := &stripe.PaymentLinkAfterCompletionParams{
+		Type: stripe.String(afterCompletion["type"].(string)),
+	}
+	if redirect := afterCompletion["redirect"].([]interface{}); len(redirect) > 0 && redirect[0] != nil {
+		params.Redirect = &stripe.PaymentLinkAfterCompletionRedirectParams{
+			URL: stripe.String(redirect[0].(map[string]interface{})["url"].(string)),
+		}
+	}
+	if hostedConfirmation := afterCompletion["hosted_confirmation"].([]interface{}); len(hostedConfirmation) > 0 && hostedConfirmation[0] != nil {
+		params.HostedConfirmation = &stripe.PaymentLinkAfterCompletionHostedConfirmationParams{}
+		if customMessage := hostedConfirmation[0].(map[string]interface{})["custom_message"].(string); customMessage != "" {
+			params.HostedConfirmation.CustomMessage = stripe.String(customMessage)
+		}
+	}
+	return params
+}
+
+func flattenPaymentLinkAfterCompletion(afterCompletion *stripe.PaymentLinkAfterCompletion) []interface{} {
+	if afterCompletion == nil {
+		return nil
+	}
+
+	out := map[string]interface{}{
+		"type": string(afterCompletion.Type),
+	}
+	if afterCompletion.Redirect != nil {
+		out["redirect"] = []interface{}{
+			map[string]interface{}{
+				"url": afterCompletion.Redirect.URL,
+			},
+		}
+	}
+	if afterCompletion.HostedConfirmation != nil {
+		out["hosted_confirmation"] = []interface{}{
+			map[string]interface{}{
+				"custom_message": afterCompletion.HostedConfirmation.CustomMessage,
+			},
+		}
+	}
+	return []interface{}{out}
+}
+
+func expandPaymentLinkShippingAddressCollection(in []interface{}) *stripe.PaymentLinkShippingAddressCollectionParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	return &stripe.PaymentLinkShippingAddressCollectionParams{
+		AllowedCountries: expandStringSlice(in[0].(map[string]interface{})["allowed_countries"].([]interface{})),
+	}
+}
+
+// addPaymentLinkCustomFields sends the custom fields, which the Stripe SDK
+// doesn't know about yet.
+func addPaymentLinkCustomFields(params *stripe.Params, in []interface{}) {
+	for i, v := range in {
+		field := v.(map[string]interface{})
+		prefix := fmt.Sprintf("custom_fields[%d]", i)
+		params.AddExtra(prefix+"[key]", field["key"].(string))
+		params.AddExtra(prefix+"[label][type]", "custom")
+		params.AddExtra(prefix+"[label][custom]", field["label"].(string))
+		params.AddExtra(prefix+"[type]", field["type"].(string))
+		params.AddExtra(prefix+"[optional]", strconv.FormatBool(field["optional"].(bool)))
+		for j, o := range field["dropdown_option"].([]interface{}) {
+			option := o.(map[string]interface{})
+			params.AddExtra(fmt.Sprintf("%s[dropdown][options][%d][label]", prefix, j), option["label"].(string))
+			params.AddExtra(fmt.Sprintf("%s[dropdown][options][%d][value]", prefix, j), option["value"].(string))
+		}
+	}
+}
+
+// paymentLinkRaw holds the attributes of payment links and their line items
+// that the Stripe SDK doesn't decode, read from the raw responses instead.
+type paymentLinkRaw struct {
+	CustomFields []struct {
+		Key   string `json:"key"`
+		Label struct {
+			Custom string `json:"custom"`
+		} `json:"label"`
+		Type     string `json:"type"`
+		Optional bool   `json:"optional"`
+		Dropdown *struct {
+			Options []struct {
+				Label string `json:"label"`
+				Value string `json:"value"`
+			} `json:"options"`
+		} `json:"dropdown"`
+	} `json:"custom_fields"`
+	Data []struct {
+		ID                 string `json:"id"`
+		AdjustableQuantity *struct {
+			Enabled bool  `json:"enabled"`
+			Minimum int64 `json:"minimum"`
+			Maximum int64 `json:"maximum"`
+		} `json:"adjustable_quantity"`
+	} `json:"data"`
+}
+
+func decodePaymentLinkRaw(response *stripe.APIResponse) (*paymentLinkRaw, error) {
+	raw := &paymentLinkRaw{}
+	if response == nil {
+		return raw, nil
+	}
+	if err := json.Unmarshal(response.RawJSON, raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func flattenPaymentLinkCustomFields(raw *paymentLinkRaw) []interface{} {
+	out := make([]interface{}, len(raw.CustomFields))
+	for i, field := range raw.CustomFields {
+		var options []interface{}
+		if field.Dropdown != nil {
+			for _, option := range field.Dropdown.Options {
+				options = append(options, map[string]interface{}{
+					"label": option.Label,
+					"value": option.Value,
+				})
+			}
+		}
+		out[i] = map[string]interface{}{
+			"key":             field.Key,
+			"label":           field.Label.Custom,
+			"type":            field.Type,
+			"optional":        field.Optional,
+			"dropdown_option": options,
+		}
+	}
+	return out
+}
+
+// resourceStripePaymentLinkListLineItems lists the payment link's line items,
+// along with their adjustable quantities.
+func resourceStripePaymentLinkListLineItems(client *Client, account, linkID string) ([]interface{}, error) {
+	params := &stripe.PaymentLinkListLineItemsParams{
+		PaymentLink: stripe.String(linkID),
+	}
+	// Payment links have up to 20 line items, all listed at once.
+	params.Limit = stripe.Int64(100)
+	setStripeAccount(params, account)
+
+	var items []*stripe.LineItem
+	i := client.PaymentLinks.ListLineItems(params)
+	for i.Next() {
+		items = append(items, i.LineItem())
+	}
+
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+
+	raw, err := decodePaymentLinkRaw(i.LineItemList().LastResponse)
+	if err != nil {
+		return nil, err
+	}
+	adjustableQuantities := map[string][]interface{}{}
+	for _, item := range raw.Data {
+		if item.AdjustableQuantity != nil && item.AdjustableQuantity.Enabled {
+			adjustableQuantities[item.ID] = []interface{}{
+				map[string]interface{}{
+					"minimum": item.AdjustableQuantity.Minimum,
+					"maximum": item.AdjustableQuantity.Maximum,
+				},
+			}
+		}
+	}
+
+	out := make([]interface{}, len(items))
+	for i, item := range items {
+		var price string
+		if item.Price != nil {
+			price = item.Price.ID
+		}
+		out[i] = map[string]interface{}{
+			"id":                  item.ID,
+			"price":               price,
+			"quantity":            item.Quantity,
+			"adjustable_quantity": adjustableQuantities[item.ID],
+		}
+	}
+	return out, nil
+}
+
+func resourceStripePaymentLinkRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.PaymentLinkParams{}
+	setStripeAccount(params, account)
+	link, err := client.PaymentLinks.Get(id, params)
+
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	lineItems, err := resourceStripePaymentLinkListLineItems(client, account, link.ID)
+	if err != nil {
+		return err
+	}
+
+	raw, err := decodePaymentLinkRaw(link.LastResponse)
+	if err != nil {
+		return err
+	}
+
+	d.Set("stripe_account", account)
+	d.Set("line_item", lineItems)
+	d.Set("after_completion", flattenPaymentLinkAfterCompletion(link.AfterCompletion))
+	d.Set("allow_promotion_codes", link.AllowPromotionCodes)
+	if link.AutomaticTax != nil {
+		d.Set("automatic_tax", flattenEnabled(link.AutomaticTax.Enabled))
+	}
+	d.Set("billing_address_collection", link.BillingAddressCollection)
+	d.Set("custom_field", flattenPaymentLinkCustomFields(raw))
+	if link.PhoneNumberCollection != nil {
+		d.Set("phone_number_collection", flattenEnabled(link.PhoneNumberCollection.Enabled))
+	}
+	if link.ShippingAddressCollection != nil {
+		d.Set("shipping_address_collection", []interface{}{
+			map[string]interface{}{
+				"allowed_countries": link.ShippingAddressCollection.AllowedCountries,
+			},
+		})
+	} else {
+		d.Set("shipping_address_collection", nil)
+	}
+	// Stripe returns subscription data for every link selling recurring
+	// prices, it's only kept when configured as it forces a new link.
+	if link.SubscriptionData != nil && (link.SubscriptionData.TrialPeriodDays != 0 || len(d.Get("subscription_data").([]interface{})) > 0) {
+		d.Set("subscription_data", []interface{}{
+			map[string]interface{}{
+				"trial_period_days": link.SubscriptionData.TrialPeriodDays,
+			},
+		})
+	} else {
+		d.Set("subscription_data", nil)
+	}
+	d.Set("active", link.Active)
+	d.Set("metadata", link.Metadata)
+	d.Set("url", link.URL)
+	d.Set("livemode", link.Livemode)
+
+	return nil
+}
+
+func resourceStripePaymentLinkUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := stripe.PaymentLinkParams{}
+	setStripeAccount(&params, account)
+
+	if d.HasChange("line_item") {
+		params.LineItems = expandPaymentLinkLineItems(d.Get("line_item").([]interface{}), true)
+	}
+
+	if d.HasChange("after_completion") {
+		params.AfterCompletion = expandPaymentLinkAfterCompletion(d.Get("after_completion").([]interface{}))
+	}
+
+	if d.HasChange("allow_promotion_codes") {
+		params.AllowPromotionCodes = stripe.Bool(d.Get("allow_promotion_codes").(bool))
+	}
+
+	if d.HasChange("automatic_tax") {
+		params.AutomaticTax = &stripe.PaymentLinkAutomaticTaxParams{
+			Enabled: stripe.Bool(expandEnabled(d.Get("automatic_tax").([]interface{}))),
+		}
+	}
+
+	if d.HasChange("billing_address_collection") {
+		params.BillingAddressCollection = stripe.String(d.Get("billing_address_collection").(string))
+	}
+
+	if d.HasChange("custom_field") {
+		if customFields := d.Get("custom_field").([]interface{}); len(customFields) > 0 {
+			addPaymentLinkCustomFields(&params.Params, customFields)
+		} else {
+			params.AddExtra("custom_fields", "")
+		}
+	}
+
+	if d.HasChange("phone_number_collection") {
+		params.PhoneNumberCollection = &stripe.PaymentLinkPhoneNumberCollectionParams{
+			Enabled: stripe.Bool(expandEnabled(d.Get("phone_number_collection").([]interface{}))),
+		}
+	}
+
+	if d.HasChange("shipping_address_collection") {
+		if shippingAddressCollection := expandPaymentLinkShippingAddressCollection(d.Get("shipping_address_collection").([]interface{})); shippingAddressCollection != nil {
+			params.ShippingAddressCollection = shippingAddressCollection
+		} else {
+			params.AddExtra("shipping_address_collection", "")
+		}
+	}
+
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(d.Get("active").(bool))
+	}
+
+	if d.HasChange("metadata") {
+		params.Metadata = expandMetadata(d)
+	}
+
+	_, err := client.PaymentLinks.Update(id, &params)
+	if err != nil {
+		return err
+	}
+
+	return resourceStripePaymentLinkRead(d, m)
+}
+
+func resourceStripePaymentLinkDelete(d *schema.ResourceData, m interface{}) error {
+	return resourceStripeArchivableDelete(d, m, "payment link", func() error {
+		client := m.(*Client)
+		account, id := resourceStripeID(d, m)
+		params := &stripe.PaymentLinkParams{
+			Active: stripe.Bool(false),
+		}
+		setStripeAccount(params, account)
+		_, err := client.PaymentLinks.Update(id, params)
+		return err
+	})
+}
@@ -0,0 +1,383 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripePaymentLink_basic(t *testing.T) {
+	var before, after stripe.PaymentLink
+	var seatsItemID string
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePaymentLinkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePaymentLinkConfig(`
+  line_item {
+    price    = stripe_price.seats.id
+    quantity = 5
+
+    adjustable_quantity {
+      minimum = 1
+      maximum = 50
+    }
+  }
+
+  line_item {
+    price = stripe_price.support.id
+  }
+
+  after_completion {
+    type = "redirect"
+
+    redirect {
+      url = "https://acme.example/welcome"
+    }
+  }
+
+  allow_promotion_codes      = true
+  billing_address_collection = "required"
+
+  automatic_tax {
+    enabled = true
+  }
+
+  phone_number_collection {
+    enabled = true
+  }
+
+  shipping_address_collection {
+    allowed_countries = ["US", "CA"]
+  }
+
+  subscription_data {
+    trial_period_days = 14
+  }
+
+  custom_field {
+    key   = "company"
+    label = "Company name"
+    type  = "text"
+  }
+
+  custom_field {
+    key      = "team_size"
+    label    = "Team size"
+    type     = "dropdown"
+    optional = true
+
+    dropdown_option {
+      label = "1-10"
+      value = "small"
+    }
+
+    dropdown_option {
+      label = "11+"
+      value = "large"
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePaymentLinkExists("stripe_payment_link.test", &before),
+					resource.TestMatchResourceAttr("stripe_payment_link.test", "url", regexp.MustCompile(`^https://buy\.stripe\.com/`)),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.#", "2"),
+					resource.TestCheckResourceAttrPair("stripe_payment_link.test", "line_item.0.price", "stripe_price.seats", "id"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.0.quantity", "5"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.0.adjustable_quantity.0.maximum", "50"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.1.quantity", "1"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.1.adjustable_quantity.#", "0"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "after_completion.0.type", "redirect"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "after_completion.0.redirect.0.url", "https://acme.example/welcome"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "allow_promotion_codes", "true"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "automatic_tax.0.enabled", "true"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "billing_address_collection", "required"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "phone_number_collection.0.enabled", "true"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "shipping_address_collection.0.allowed_countries.#", "2"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "subscription_data.0.trial_period_days", "14"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "custom_field.#", "2"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "custom_field.1.optional", "true"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "custom_field.1.dropdown_option.1.value", "large"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "metadata.campaign", "spring"),
+					testAccCheckStripePaymentLinkItemID("stripe_payment_link.test", 0, &seatsItemID),
+				),
+			},
+			{
+				// Quantities and most settings are updated in place.
+				Config: testAccStripePaymentLinkConfig(`
+  line_item {
+    price    = stripe_price.seats.id
+    quantity = 10
+  }
+
+  line_item {
+    price = stripe_price.support.id
+  }
+
+  after_completion {
+    type = "hosted_confirmation"
+
+    hosted_confirmation {
+      custom_message = "Thanks!"
+    }
+  }
+
+  automatic_tax {
+    enabled = false
+  }
+
+  subscription_data {
+    trial_period_days = 14
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePaymentLinkExists("stripe_payment_link.test", &after),
+					testAccCheckStripePaymentLinkNotRecreated(&before, &after),
+					testAccCheckStripePaymentLinkItemID("stripe_payment_link.test", 0, &seatsItemID),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.0.quantity", "10"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.0.adjustable_quantity.#", "0"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "after_completion.0.type", "hosted_confirmation"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "after_completion.0.hosted_confirmation.0.custom_message", "Thanks!"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "allow_promotion_codes", "false"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "automatic_tax.0.enabled", "false"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "billing_address_collection", "auto"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "shipping_address_collection.#", "0"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "custom_field.#", "0"),
+				),
+			},
+			{
+				// Line items can be added in place.
+				Config: testAccStripePaymentLinkConfig(`
+  line_item {
+    price    = stripe_price.seats.id
+    quantity = 10
+  }
+
+  line_item {
+    price = stripe_price.support.id
+  }
+
+  line_item {
+    price    = stripe_price.onboarding.id
+    quantity = 2
+  }
+
+  subscription_data {
+    trial_period_days = 14
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePaymentLinkExists("stripe_payment_link.test", &after),
+					testAccCheckStripePaymentLinkNotRecreated(&before, &after),
+					testAccCheckStripePaymentLinkItemID("stripe_payment_link.test", 0, &seatsItemID),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.#", "3"),
+					resource.TestCheckResourceAttrPair("stripe_payment_link.test", "line_item.2.price", "stripe_price.onboarding", "id"),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.2.quantity", "2"),
+				),
+			},
+			{
+				// And removed in place.
+				Config: testAccStripePaymentLinkConfig(`
+  line_item {
+    price    = stripe_price.seats.id
+    quantity = 10
+  }
+
+  line_item {
+    price = stripe_price.support.id
+  }
+
+  subscription_data {
+    trial_period_days = 14
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePaymentLinkExists("stripe_payment_link.test", &after),
+					testAccCheckStripePaymentLinkNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "line_item.#", "2"),
+				),
+			},
+			{
+				// Changing the price of a line item replaces the link.
+				Config: testAccStripePaymentLinkConfig(`
+  line_item {
+    price    = stripe_price.seats.id
+    quantity = 10
+  }
+
+  line_item {
+    price = stripe_price.onboarding.id
+  }
+
+  subscription_data {
+    trial_period_days = 14
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePaymentLinkExists("stripe_payment_link.test", &after),
+					testAccCheckStripePaymentLinkRecreated(&before, &after),
+					resource.TestCheckResourceAttrPair("stripe_payment_link.test", "line_item.1.price", "stripe_price.onboarding", "id"),
+				),
+			},
+			{
+				ResourceName:            "stripe_payment_link.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"on_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccStripePaymentLink_noSubscriptionData(t *testing.T) {
+	var link stripe.PaymentLink
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePaymentLinkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePaymentLinkConfig(`
+  line_item {
+    price = stripe_price.seats.id
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePaymentLinkExists("stripe_payment_link.test", &link),
+					resource.TestCheckResourceAttr("stripe_payment_link.test", "subscription_data.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStripePaymentLinkExists(n string, link *stripe.PaymentLink) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.PaymentLinks.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*link = *found
+		return nil
+	}
+}
+
+func testAccCheckStripePaymentLinkNotRecreated(before, after *stripe.PaymentLink) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID != after.ID {
+			return fmt.Errorf("expected payment link %s to be updated, it was replaced by %s", before.ID, after.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckStripePaymentLinkRecreated(before, after *stripe.PaymentLink) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID == after.ID {
+			return fmt.Errorf("expected payment link %s to be replaced", before.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckStripePaymentLinkItemID checks that the line item at the given
+// index keeps the same ID, i.e. it was updated rather than replaced.
+func testAccCheckStripePaymentLinkItemID(n string, index int, itemID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		id := rs.Primary.Attributes[fmt.Sprintf("line_item.%d.id", index)]
+		if id == "" {
+			return fmt.Errorf("line item %d of %s has no ID", index, rs.Primary.ID)
+		}
+		if *itemID == "" {
+			*itemID = id
+		} else if *itemID != id {
+			return fmt.Errorf("expected line item %s to be updated, it was replaced by %s", *itemID, id)
+		}
+		return nil
+	}
+}
+
+// Payment links can't be deleted, destroying them deactivates them.
+func testAccCheckStripePaymentLinkDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_payment_link" {
+			continue
+		}
+
+		link, err := client.PaymentLinks.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if link.Active {
+			return fmt.Errorf("payment link %s should have been deactivated", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStripePaymentLinkConfig(settings string) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Platform"
+  type = "service"
+}
+
+resource "stripe_price" "seats" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 2000
+
+  recurring {
+    interval = "month"
+  }
+}
+
+resource "stripe_price" "support" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 50000
+
+  recurring {
+    interval = "month"
+  }
+}
+
+resource "stripe_price" "onboarding" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 100000
+}
+
+resource "stripe_payment_link" "test" {
+%s
+  metadata = {
+    campaign = "spring"
+  }
+}
+`, settings)
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	stripe "github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripePlan() *schema.Resource {
@@ -168,12 +167,20 @@ func resourceStripePlan() *schema.Resource {
 				ForceNew: true,
 				Default:  "licensed",
 			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
 		},
 	}
 }
 
 func resourceStripePlanCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
 	planNickname := d.Get("nickname").(string)
 	planInterval := d.Get("interval").(string)
 	planCurrency := d.Get("currency").(string)
@@ -201,9 +208,7 @@ func resourceStripePlanCreate(d *schema.ResourceData, m interface{}) error {
 		params.ID = stripe.String(id.(string))
 	}
 
-	if active, ok := d.GetOk("active"); ok {
-		params.Active = stripe.Bool(active.(bool))
-	}
+	params.Active = stripe.Bool(d.Get("active").(bool))
 
 	if aggregateUsage, ok := d.GetOk("aggregate_usage"); ok {
 		params.AggregateUsage = stripe.String(aggregateUsage.(string))
@@ -247,7 +252,16 @@ func resourceStripePlanCreate(d *schema.ResourceData, m interface{}) error {
 		params.UsageType = stripe.String(usageType.(string))
 	}
 
-	plan, err := client.Plans.New(params)
+	setStripeAccount(params, account)
+	var plan *stripe.Plan
+	err := createIdempotently(client, "plans", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		plan, err = client.Plans.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return plan.ID, plan.LastResponse, nil
+	})
 
 	if err == nil {
 		if planNickname != "" {
@@ -255,41 +269,52 @@ func resourceStripePlanCreate(d *schema.ResourceData, m interface{}) error {
 		} else {
 			log.Printf("[INFO] Create anonymous plan: %s", plan.ID)
 		}
-		d.SetId(plan.ID)
+		d.SetId(stripeResourceID(account, plan.ID))
 	}
 
 	return err
 }
 
 func resourceStripePlanRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	plan, err := client.Plans.Get(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.PlanParams{}
+	params.AddExpand("tiers") // tiers are only returned when expanded
+	setStripeAccount(params, account)
+	plan, err := client.Plans.Get(id, params)
 
 	if err != nil {
 		d.SetId("")
 	} else {
-		d.Set("plan_id", plan.ID)
-		d.Set("active", plan.Active)
-		d.Set("aggregate_usage", plan.AggregateUsage)
-		d.Set("amount", plan.Amount)
-		d.Set("amount_decimal", plan.AmountDecimal)
-		d.Set("billing_scheme", plan.BillingScheme)
-		d.Set("currency", plan.Currency)
-		d.Set("interval", plan.Interval)
-		d.Set("interval_count", plan.IntervalCount)
-		d.Set("metadata", plan.Metadata)
-		d.Set("nickname", plan.Nickname)
-		d.Set("product", plan.Product)
-		d.Set("tiers_mode", plan.TiersMode)
-		d.Set("tier", flattenPlanTiers(plan.Tiers))
-		d.Set("transform_usage", flattenPlanTransformUsage(plan.TransformUsage))
-		d.Set("trial_period_days", plan.TrialPeriodDays)
-		d.Set("usage_type", plan.UsageType)
+		d.Set("stripe_account", account)
+		flattenPlan(d, plan)
 	}
 
 	return err
 }
 
+func flattenPlan(d *schema.ResourceData, plan *stripe.Plan) {
+	d.Set("plan_id", plan.ID)
+	d.Set("active", plan.Active)
+	d.Set("aggregate_usage", plan.AggregateUsage)
+	d.Set("amount", plan.Amount)
+	d.Set("amount_decimal", plan.AmountDecimal)
+	d.Set("billing_scheme", plan.BillingScheme)
+	d.Set("currency", plan.Currency)
+	d.Set("interval", plan.Interval)
+	d.Set("interval_count", plan.IntervalCount)
+	d.Set("metadata", plan.Metadata)
+	d.Set("nickname", plan.Nickname)
+	if plan.Product != nil {
+		d.Set("product", plan.Product.ID)
+	}
+	d.Set("tiers_mode", plan.TiersMode)
+	d.Set("tier", flattenPlanTiers(plan.Tiers))
+	d.Set("transform_usage", flattenPlanTransformUsage(plan.TransformUsage))
+	d.Set("trial_period_days", plan.TrialPeriodDays)
+	d.Set("usage_type", plan.UsageType)
+}
+
 func flattenPlanTiers(in []*stripe.PlanTier) []map[string]interface{} {
 	out := make([]map[string]interface{}, len(in))
 	for i, tier := range in {
@@ -309,19 +334,28 @@ func expandPlanTiers(in []interface{}) []*stripe.PlanTierParams {
 	out := make([]*stripe.PlanTierParams, len(in))
 	for i, v := range in {
 		tier := v.(map[string]interface{})
-		out[i] = &stripe.PlanTierParams{
-			UpTo:    stripe.Int64(int64(tier["up_to"].(int))),
-			UpToInf: stripe.Bool(tier["up_to_inf"].(bool)),
+		out[i] = &stripe.PlanTierParams{}
+		if tier["up_to_inf"].(bool) {
+			out[i].UpToInf = stripe.Bool(true)
+		} else {
+			out[i].UpTo = stripe.Int64(int64(tier["up_to"].(int)))
+		}
+		// Amounts are computed from their decimal counterpart and vice versa,
+		// so only send the one that is set.
+		if flatAmount := tier["flat_amount"].(int); flatAmount != 0 {
+			out[i].FlatAmount = stripe.Int64(int64(flatAmount))
+		} else if flatAmountDecimal := tier["flat_amount_decimal"].(float64); flatAmountDecimal != 0 {
+			out[i].FlatAmountDecimal = stripe.Float64(flatAmountDecimal)
 		}
-		if tier["flat_amount"] != nil {
-			out[i].FlatAmount = stripe.Int64(int64(tier["flat_amount"].(int)))
-		} else if tier["flat_amount_decimal"] != nil {
-			out[i].FlatAmountDecimal = stripe.Float64(tier["flat_amount_decimal"].(float64))
+		if unitAmount := tier["unit_amount"].(int); unitAmount != 0 {
+			out[i].UnitAmount = stripe.Int64(int64(unitAmount))
+		} else if unitAmountDecimal := tier["unit_amount_decimal"].(float64); unitAmountDecimal != 0 {
+			out[i].UnitAmountDecimal = stripe.Float64(unitAmountDecimal)
 		}
-		if tier["unit_amount"] != nil {
-			out[i].UnitAmount = stripe.Int64(int64(tier["unit_amount"].(int)))
-		} else if tier["unit_amount_decimal"] != nil {
-			out[i].UnitAmountDecimal = stripe.Float64(tier["unit_amount_decimal"].(float64))
+		// Tiers need an amount, and zeros can't be told apart from amounts
+		// left out: tiers without any amount are free.
+		if out[i].FlatAmount == nil && out[i].FlatAmountDecimal == nil && out[i].UnitAmount == nil && out[i].UnitAmountDecimal == nil {
+			out[i].UnitAmount = stripe.Int64(0)
 		}
 	}
 	return out
@@ -357,8 +391,10 @@ func expandPlanTransformUsage(in []interface{}) *stripe.PlanTransformUsageParams
 }
 
 func resourceStripePlanUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
 	params := stripe.PlanParams{}
+	setStripeAccount(&params, account)
 
 	if d.HasChange("plan_id") {
 		params.ID = stripe.String(d.Get("plan_id").(string))
@@ -380,7 +416,7 @@ func resourceStripePlanUpdate(d *schema.ResourceData, m interface{}) error {
 		params.TrialPeriodDays = stripe.Int64(int64(d.Get("trial_period_days").(int)))
 	}
 
-	_, err := client.Plans.Update(d.Id(), &params)
+	_, err := client.Plans.Update(id, &params)
 
 	if err != nil {
 		return err
@@ -390,8 +426,11 @@ func resourceStripePlanUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceStripePlanDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	_, err := client.Plans.Del(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.PlanParams{}
+	setStripeAccount(params, account)
+	_, err := client.Plans.Del(id, params)
 
 	if err == nil {
 		d.SetId("")
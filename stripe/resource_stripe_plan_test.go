@@ -0,0 +1,263 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripePlan_basic(t *testing.T) {
+	var plan stripe.Plan
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePlanDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePlanConfig("Monthly", 14),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePlanExists("stripe_plan.test", &plan),
+					resource.TestCheckResourceAttrPair("stripe_plan.test", "product", "stripe_product.test", "id"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "nickname", "Monthly"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "amount", "12345"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "currency", "usd"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "interval", "month"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "interval_count", "1"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "billing_scheme", "per_unit"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "usage_type", "licensed"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "trial_period_days", "14"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "metadata.plan", "monthly"),
+				),
+			},
+			{
+				Config: testAccStripePlanConfig("Monthly (legacy)", 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePlanExists("stripe_plan.test", &plan),
+					resource.TestCheckResourceAttr("stripe_plan.test", "nickname", "Monthly (legacy)"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "trial_period_days", "30"),
+				),
+			},
+			{
+				ResourceName:      "stripe_plan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePlan_transformUsage(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePlanDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Metered"
+  type = "service"
+}
+
+resource "stripe_plan" "test" {
+  plan_id         = "plan_acceptance_metered"
+  product         = stripe_product.test.id
+  amount_decimal  = 0.5
+  currency        = "eur"
+  interval        = "week"
+  interval_count  = 2
+  usage_type      = "metered"
+  aggregate_usage = "sum"
+
+  transform_usage {
+    divide_by = 100
+    round     = "up"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_plan.test", "id", "plan_acceptance_metered"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "amount_decimal", "0.5"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "interval_count", "2"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "aggregate_usage", "sum"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "transform_usage.0.divide_by", "100"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "transform_usage.0.round", "up"),
+				),
+			},
+			{
+				ResourceName:      "stripe_plan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePlan_tiers(t *testing.T) {
+	var plan stripe.Plan
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePlanDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePlanConfigTiers,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePlanExists("stripe_plan.test", &plan),
+					resource.TestCheckResourceAttr("stripe_plan.test", "billing_scheme", "tiered"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "tiers_mode", "volume"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "tier.#", "2"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "tier.0.up_to", "100"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "tier.0.unit_amount", "200"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "tier.1.up_to_inf", "true"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "tier.1.unit_amount", "150"),
+				),
+			},
+			{
+				ResourceName:      "stripe_plan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Tiers edited outside of Terraform show up as drift.
+				PreConfig: func() {
+					testAccStripeMock.Put("plans", plan.ID, map[string]interface{}{
+						"tiers": []interface{}{
+							map[string]interface{}{"up_to": int64(100), "unit_amount": int64(200), "unit_amount_decimal": "200"},
+							map[string]interface{}{"up_to": nil, "unit_amount": int64(100), "unit_amount_decimal": "100"},
+						},
+					})
+				},
+				Config:             testAccStripePlanConfigTiers,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePlan_freeTier(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePlanDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Freemium"
+}
+
+resource "stripe_plan" "test" {
+  product        = stripe_product.test.id
+  currency       = "usd"
+  interval       = "month"
+  billing_scheme = "tiered"
+  tiers_mode     = "graduated"
+
+  tier {
+    up_to       = 5
+    unit_amount = 0
+  }
+
+  tier {
+    up_to_inf   = true
+    unit_amount = 1000
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_plan.test", "tier.#", "2"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "tier.0.unit_amount", "0"),
+					resource.TestCheckResourceAttr("stripe_plan.test", "tier.1.unit_amount", "1000"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStripePlanExists(n string, plan *stripe.Plan) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.Plans.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*plan = *found
+		return nil
+	}
+}
+
+func testAccCheckStripePlanDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_plan" {
+			continue
+		}
+
+		if _, err := client.Plans.Get(rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("plan %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStripePlanConfig(nickname string, trialPeriodDays int) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Subscription"
+  type = "service"
+}
+
+resource "stripe_plan" "test" {
+  product           = stripe_product.test.id
+  nickname          = "%s"
+  amount            = 12345
+  interval          = "month"
+  currency          = "usd"
+  trial_period_days = %d
+
+  metadata = {
+    plan = "monthly"
+  }
+}
+`, nickname, trialPeriodDays)
+}
+
+const testAccStripePlanConfigTiers = `
+resource "stripe_product" "test" {
+  name = "Volume"
+  type = "service"
+}
+
+resource "stripe_plan" "test" {
+  product        = stripe_product.test.id
+  currency       = "usd"
+  interval       = "month"
+  billing_scheme = "tiered"
+  tiers_mode     = "volume"
+
+  tier {
+    up_to       = 100
+    unit_amount = 200
+  }
+
+  tier {
+    up_to_inf   = true
+    unit_amount = 150
+  }
+}
+`
@@ -1,14 +1,14 @@
 package stripe
 
 import (
-	"errors"
 	"fmt"
 	"log"
-	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	stripe "github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripePrice() *schema.Resource {
@@ -18,7 +18,24 @@ func resourceStripePrice() *schema.Resource {
 		Update: resourceStripePriceUpdate,
 		Delete: resourceStripePriceDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceStripePriceImport,
+		},
+
+		// A price's tax behavior can only be set once, when it is unspecified.
+		CustomizeDiff: customdiff.Sequence(
+			customdiff.ForceNewIfChange("tax_behavior", func(old, new, meta interface{}) bool {
+				return old.(string) != "" && old.(string) != "unspecified"
+			}),
+			resourceStripePriceValidateCustomUnitAmount,
+		),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceStripePriceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceStripePriceStateUpgradeV0,
+				Version: 0,
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -49,17 +66,61 @@ func resourceStripePrice() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"lookup_key": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 200),
+			},
+			"transfer_lookup_key": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"product": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
 			},
 			"recurring": &schema.Schema{
-				Type: schema.TypeMap,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+				Type:     schema.TypeList,
 				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"interval": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"day", "week", "month", "year"}, false),
+						},
+						"interval_count": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"usage_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "licensed",
+							ValidateFunc: validation.StringInSlice([]string{"licensed", "metered"}, false),
+						},
+						"aggregate_usage": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true, // defaults to sum for metered prices
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"sum", "last_during_period", "last_ever", "max"}, false),
+						},
+						"trial_period_days": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
 			},
 			"unit_amount": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -76,7 +137,71 @@ func resourceStripePrice() *schema.Resource {
 			"billing_scheme": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"tax_behavior": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true, // unspecified by default
+				ValidateFunc: validation.StringInSlice([]string{"inclusive", "exclusive", "unspecified"}, false),
+			},
+			"custom_unit_amount": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"unit_amount", "unit_amount_decimal", "tier"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  true,
+						},
+						"minimum": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"maximum": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"preset": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"transform_quantity": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
 				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"divide_by": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"round": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"down", "up"}, false),
+						},
+					},
+				},
 			},
 			"created": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -136,39 +261,228 @@ func resourceStripePrice() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"currency_options": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"currency": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"unit_amount": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"unit_amount_decimal": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Optional: true,
+						},
+						"tax_behavior": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"inclusive", "exclusive"}, false),
+						},
+						"tier": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"up_to": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"up_to_inf": &schema.Schema{
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"flat_amount": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"flat_amount_decimal": &schema.Schema{
+										Type:     schema.TypeFloat,
+										Optional: true,
+									},
+									"unit_amount": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"unit_amount_decimal": &schema.Schema{
+										Type:     schema.TypeFloat,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"custom_unit_amount": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"minimum": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"maximum": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"preset": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"on_destroy": onDestroySchema(),
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
 		},
 	}
 }
 
-func expandPriceRecurring(recurring map[string]interface{}) (*stripe.PriceRecurringParams, error) {
-	params := &stripe.PriceRecurringParams{}
-	parsed := expandStringMap(recurring)
+func expandPriceRecurring(in []interface{}) *stripe.PriceRecurringParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
 
-	if aggregateUsage, ok := parsed["aggregate_usage"]; ok {
+	recurring := in[0].(map[string]interface{})
+	params := &stripe.PriceRecurringParams{
+		Interval:      stripe.String(recurring["interval"].(string)),
+		IntervalCount: stripe.Int64(int64(recurring["interval_count"].(int))),
+		UsageType:     stripe.String(recurring["usage_type"].(string)),
+	}
+
+	if aggregateUsage := recurring["aggregate_usage"].(string); aggregateUsage != "" {
 		params.AggregateUsage = stripe.String(aggregateUsage)
 	}
 
-	if interval, ok := parsed["interval"]; ok {
-		params.Interval = stripe.String(interval)
+	if trialPeriodDays := recurring["trial_period_days"].(int); trialPeriodDays > 0 {
+		params.TrialPeriodDays = stripe.Int64(int64(trialPeriodDays))
 	}
 
-	if intervalCount, ok := parsed["interval_count"]; ok {
-		intervalCountInt, err := strconv.ParseInt(intervalCount, 10, 64)
-		if err != nil {
-			return nil, errors.New("interval_count must be a string, representing an int (e.g. \"52\")")
-		}
-		params.IntervalCount = stripe.Int64(intervalCountInt)
+	return params
+}
+
+func flattenPriceRecurring(recurring *stripe.PriceRecurring) []interface{} {
+	if recurring == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"interval":          string(recurring.Interval),
+			"interval_count":    recurring.IntervalCount,
+			"usage_type":        string(recurring.UsageType),
+			"aggregate_usage":   string(recurring.AggregateUsage),
+			"trial_period_days": recurring.TrialPeriodDays,
+		},
+	}
+}
+
+func expandPriceCustomUnitAmount(in []interface{}) *stripe.PriceCustomUnitAmountParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	customUnitAmount := in[0].(map[string]interface{})
+	params := &stripe.PriceCustomUnitAmountParams{
+		Enabled: stripe.Bool(customUnitAmount["enabled"].(bool)),
 	}
 
-	if usageType, ok := parsed["usage_type"]; ok {
-		params.UsageType = stripe.String(usageType)
+	if minimum := customUnitAmount["minimum"].(int); minimum > 0 {
+		params.Minimum = stripe.Int64(int64(minimum))
 	}
 
-	return params, nil
+	if maximum := customUnitAmount["maximum"].(int); maximum > 0 {
+		params.Maximum = stripe.Int64(int64(maximum))
+	}
+
+	if preset := customUnitAmount["preset"].(int); preset > 0 {
+		params.Preset = stripe.Int64(int64(preset))
+	}
+
+	return params
+}
+
+func flattenPriceCustomUnitAmount(customUnitAmount *stripe.PriceCustomUnitAmount) []interface{} {
+	if customUnitAmount == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"enabled": true,
+			"minimum": customUnitAmount.Minimum,
+			"maximum": customUnitAmount.Maximum,
+			"preset":  customUnitAmount.Preset,
+		},
+	}
+}
+
+// resourceStripePriceValidateCustomUnitAmount checks that the preset amount
+// lies between the minimum and the maximum ones. Stripe only returns custom
+// unit amounts that are enabled, so disabling one means removing the block.
+func resourceStripePriceValidateCustomUnitAmount(d *schema.ResourceDiff, meta interface{}) error {
+	customUnitAmount, ok := d.GetOk("custom_unit_amount")
+	if !ok || len(customUnitAmount.([]interface{})) == 0 || customUnitAmount.([]interface{})[0] == nil {
+		return nil
+	}
+
+	c := customUnitAmount.([]interface{})[0].(map[string]interface{})
+	minimum, maximum, preset := c["minimum"].(int), c["maximum"].(int), c["preset"].(int)
+
+	if !c["enabled"].(bool) {
+		return fmt.Errorf("custom_unit_amount.0.enabled can't be false, remove the custom_unit_amount block instead")
+	}
+	if maximum > 0 && minimum > maximum {
+		return fmt.Errorf("custom_unit_amount.0.minimum (%d) can't be greater than custom_unit_amount.0.maximum (%d)", minimum, maximum)
+	}
+	if preset > 0 && (preset < minimum || (maximum > 0 && preset > maximum)) {
+		return fmt.Errorf("custom_unit_amount.0.preset (%d) must be between custom_unit_amount.0.minimum and custom_unit_amount.0.maximum", preset)
+	}
+
+	return nil
+}
+
+func expandPriceTransformQuantity(in []interface{}) *stripe.PriceTransformQuantityParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	transformQuantity := in[0].(map[string]interface{})
+	return &stripe.PriceTransformQuantityParams{
+		DivideBy: stripe.Int64(int64(transformQuantity["divide_by"].(int))),
+		Round:    stripe.String(transformQuantity["round"].(string)),
+	}
+}
+
+func flattenPriceTransformQuantity(transformQuantity *stripe.PriceTransformQuantity) []interface{} {
+	if transformQuantity == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"divide_by": transformQuantity.DivideBy,
+			"round":     string(transformQuantity.Round),
+		},
+	}
 }
 
 func resourceStripePriceCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
 	nickname := d.Get("nickname").(string)
 	currency := d.Get("currency").(string)
 
@@ -176,9 +490,7 @@ func resourceStripePriceCreate(d *schema.ResourceData, m interface{}) error {
 		Currency: stripe.String(currency),
 	}
 
-	if active, ok := d.GetOk("active"); ok {
-		params.Active = stripe.Bool(active.(bool))
-	}
+	params.Active = stripe.Bool(d.Get("active").(bool))
 
 	params.Metadata = expandMetadata(d)
 
@@ -186,6 +498,11 @@ func resourceStripePriceCreate(d *schema.ResourceData, m interface{}) error {
 		params.Nickname = stripe.String(nickname)
 	}
 
+	if lookupKey, ok := d.GetOk("lookup_key"); ok {
+		params.LookupKey = stripe.String(lookupKey.(string))
+		params.TransferLookupKey = stripe.Bool(d.Get("transfer_lookup_key").(bool))
+	}
+
 	if tiersMode, ok := d.GetOk("tiers_mode"); ok {
 		params.TiersMode = stripe.String(tiersMode.(string))
 	}
@@ -198,12 +515,12 @@ func resourceStripePriceCreate(d *schema.ResourceData, m interface{}) error {
 		params.Product = stripe.String(product.(string))
 	}
 
+	if currencyOptions, ok := d.GetOk("currency_options"); ok {
+		params.CurrencyOptions = expandPriceCurrencyOptions(currencyOptions.(*schema.Set).List())
+	}
+
 	if recurring, ok := d.GetOk("recurring"); ok {
-		recurringParams, err := expandPriceRecurring(recurring.(map[string]interface{}))
-		if err != nil {
-			return err
-		}
-		params.Recurring = recurringParams
+		params.Recurring = expandPriceRecurring(recurring.([]interface{}))
 	}
 
 	if unitAmount, ok := d.GetOk("unit_amount"); ok {
@@ -218,46 +535,82 @@ func resourceStripePriceCreate(d *schema.ResourceData, m interface{}) error {
 		params.BillingScheme = stripe.String(billingScheme.(string))
 	}
 
-	price, err := client.Prices.New(params)
+	if taxBehavior, ok := d.GetOk("tax_behavior"); ok {
+		params.TaxBehavior = stripe.String(taxBehavior.(string))
+	}
+
+	if customUnitAmount, ok := d.GetOk("custom_unit_amount"); ok {
+		params.CustomUnitAmount = expandPriceCustomUnitAmount(customUnitAmount.([]interface{}))
+	}
+
+	if transformQuantity, ok := d.GetOk("transform_quantity"); ok {
+		params.TransformQuantity = expandPriceTransformQuantity(transformQuantity.([]interface{}))
+	}
+
+	setStripeAccount(params, account)
+	var price *stripe.Price
+	err := createIdempotently(client, "prices", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		price, err = client.Prices.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return price.ID, price.LastResponse, nil
+	})
 	if err != nil {
 		return err
 	}
 
 	log.Printf("[INFO] Created Stripe price: %s", nickname)
-	d.SetId(price.ID)
+	d.SetId(stripeResourceID(account, price.ID))
 
 	return resourceStripePriceRead(d, m)
 }
 
 func resourceStripePriceRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	price, err := client.Prices.Get(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.PriceParams{}
+	// tiers and currency_options are only returned when expanded
+	params.AddExpand("tiers")
+	params.AddExpand("currency_options")
+	setStripeAccount(params, account)
+	price, err := client.Prices.Get(id, params)
 
 	if err != nil {
 		d.SetId("")
 	} else {
-		d.Set("price_id", price.ID)
-		d.Set("active", price.Active)
-		d.Set("created", price.Created)
-		d.Set("currency", price.Currency)
-		d.Set("livemode", price.Livemode)
-		d.Set("metadata", price.Metadata)
-		d.Set("nickname", price.Nickname)
-		if price.Product != nil {
-			d.Set("product", price.Product.ID)
-		}
-		d.Set("recurring", price.Active)
-		d.Set("unit_amount", price.UnitAmount)
-		d.Set("unit_amount_decimal", price.UnitAmountDecimal)
-		d.Set("tiers_mode", price.TiersMode)
-		// Stripe's API doesn't return tiers.
-		// d.Set("tier", flattenPriceTiers(price.Tiers))
-		d.Set("billing_scheme", price.BillingScheme)
+		d.Set("stripe_account", account)
+		flattenPrice(d, price)
 	}
 
 	return err
 }
 
+func flattenPrice(d *schema.ResourceData, price *stripe.Price) {
+	d.Set("price_id", price.ID)
+	d.Set("active", price.Active)
+	d.Set("created", price.Created)
+	d.Set("currency", price.Currency)
+	d.Set("livemode", price.Livemode)
+	d.Set("metadata", price.Metadata)
+	d.Set("nickname", price.Nickname)
+	d.Set("lookup_key", price.LookupKey)
+	if price.Product != nil {
+		d.Set("product", price.Product.ID)
+	}
+	d.Set("recurring", flattenPriceRecurring(price.Recurring))
+	d.Set("unit_amount", price.UnitAmount)
+	d.Set("unit_amount_decimal", price.UnitAmountDecimal)
+	d.Set("tiers_mode", price.TiersMode)
+	d.Set("tier", flattenPriceTiers(price.Tiers))
+	d.Set("currency_options", flattenPriceCurrencyOptions(price))
+	d.Set("billing_scheme", price.BillingScheme)
+	d.Set("tax_behavior", price.TaxBehavior)
+	d.Set("custom_unit_amount", flattenPriceCustomUnitAmount(price.CustomUnitAmount))
+	d.Set("transform_quantity", flattenPriceTransformQuantity(price.TransformQuantity))
+}
+
 func flattenPriceTiers(in []*stripe.PriceTier) []map[string]interface{} {
 	out := make([]map[string]interface{}, len(in))
 	for i, tier := range in {
@@ -277,27 +630,71 @@ func expandPriceTiers(in []interface{}) []*stripe.PriceTierParams {
 	out := make([]*stripe.PriceTierParams, len(in))
 	for i, v := range in {
 		tier := v.(map[string]interface{})
-		out[i] = &stripe.PriceTierParams{
-			UpTo:    stripe.Int64(int64(tier["up_to"].(int))),
-			UpToInf: stripe.Bool(tier["up_to_inf"].(bool)),
+		out[i] = &stripe.PriceTierParams{}
+		if tier["up_to_inf"].(bool) {
+			out[i].UpToInf = stripe.Bool(true)
+		} else {
+			out[i].UpTo = stripe.Int64(int64(tier["up_to"].(int)))
+		}
+		// Amounts are computed from their decimal counterpart and vice versa,
+		// so only send the one that is set.
+		if flatAmount := tier["flat_amount"].(int); flatAmount != 0 {
+			out[i].FlatAmount = stripe.Int64(int64(flatAmount))
+		} else if flatAmountDecimal := tier["flat_amount_decimal"].(float64); flatAmountDecimal != 0 {
+			out[i].FlatAmountDecimal = stripe.Float64(flatAmountDecimal)
 		}
-		if tier["flat_amount"] != nil {
-			out[i].FlatAmount = stripe.Int64(int64(tier["flat_amount"].(int)))
-		} else if tier["flat_amount_decimal"] != nil {
-			out[i].FlatAmountDecimal = stripe.Float64(tier["flat_amount_decimal"].(float64))
+		if unitAmount := tier["unit_amount"].(int); unitAmount != 0 {
+			out[i].UnitAmount = stripe.Int64(int64(unitAmount))
+		} else if unitAmountDecimal := tier["unit_amount_decimal"].(float64); unitAmountDecimal != 0 {
+			out[i].UnitAmountDecimal = stripe.Float64(unitAmountDecimal)
 		}
-		if tier["unit_amount"] != nil {
-			out[i].UnitAmount = stripe.Int64(int64(tier["unit_amount"].(int)))
-		} else if tier["unit_amount_decimal"] != nil {
-			out[i].UnitAmountDecimal = stripe.Float64(tier["unit_amount_decimal"].(float64))
+		// Tiers need an amount, and zeros can't be told apart from amounts
+		// left out: tiers without any amount are free.
+		if out[i].FlatAmount == nil && out[i].FlatAmountDecimal == nil && out[i].UnitAmount == nil && out[i].UnitAmountDecimal == nil {
+			out[i].UnitAmount = stripe.Int64(0)
 		}
 	}
 	return out
 }
 
+// resourceStripePriceImport imports prices by ID, or by lookup key when given
+// `lookup_key:<key>`.
+func resourceStripePriceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	account, id := resourceStripeID(d, m)
+	if !strings.HasPrefix(id, "lookup_key:") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	client := m.(*Client)
+	lookupKey := strings.TrimPrefix(id, "lookup_key:")
+	params := &stripe.PriceListParams{
+		LookupKeys: stripe.StringSlice([]string{lookupKey}),
+	}
+	setStripeAccount(params, account)
+
+	var prices []*stripe.Price
+	i := client.Prices.List(params)
+	for i.Next() {
+		prices = append(prices, i.Price())
+	}
+
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(prices) != 1 {
+		return nil, fmt.Errorf("expected exactly one price with lookup key %q, found %d", lookupKey, len(prices))
+	}
+
+	d.SetId(stripeResourceID(account, prices[0].ID))
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceStripePriceUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
 	params := stripe.PriceParams{}
+	setStripeAccount(&params, account)
 
 	if d.HasChange("active") {
 		params.Active = stripe.Bool(d.Get("active").(bool))
@@ -311,7 +708,25 @@ func resourceStripePriceUpdate(d *schema.ResourceData, m interface{}) error {
 		params.Nickname = stripe.String(d.Get("nickname").(string))
 	}
 
-	_, err := client.Prices.Update(d.Id(), &params)
+	if d.HasChange("lookup_key") {
+		params.LookupKey = stripe.String(d.Get("lookup_key").(string))
+		params.TransferLookupKey = stripe.Bool(d.Get("transfer_lookup_key").(bool))
+	}
+
+	// Only reached when going from unspecified, other changes replace the price.
+	if d.HasChange("tax_behavior") {
+		params.TaxBehavior = stripe.String(d.Get("tax_behavior").(string))
+	}
+
+	if d.HasChange("currency_options") {
+		old, new := d.GetChange("currency_options")
+		params.CurrencyOptions = expandPriceCurrencyOptions(new.(*schema.Set).List())
+		for _, currency := range removedCurrencies(old.(*schema.Set), new.(*schema.Set)) {
+			params.AddExtra("currency_options["+currency+"]", "")
+		}
+	}
+
+	_, err := client.Prices.Update(id, &params)
 	if err != nil {
 		return err
 	}
@@ -320,5 +735,106 @@ func resourceStripePriceUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceStripePriceDelete(d *schema.ResourceData, m interface{}) error {
-	return fmt.Errorf("[WARNING] Stripe doesn't allow deleting prices via the API. Your state file contains at least one (\"%v\") that needs deletion. Please remove it manually.", d.Id())
+	return resourceStripeUndeletableDelete(d, m, "price", func() error {
+		client := m.(*Client)
+		account, id := resourceStripeID(d, m)
+		params := &stripe.PriceParams{
+			Active: stripe.Bool(false),
+		}
+		setStripeAccount(params, account)
+		_, err := client.Prices.Update(id, params)
+		return err
+	})
+}
+
+func expandPriceCurrencyOptions(in []interface{}) map[string]*stripe.PriceCurrencyOptionsParams {
+	out := make(map[string]*stripe.PriceCurrencyOptionsParams, len(in))
+	for _, v := range in {
+		option := v.(map[string]interface{})
+		params := &stripe.PriceCurrencyOptionsParams{}
+
+		if unitAmount := option["unit_amount"].(int); unitAmount != 0 {
+			params.UnitAmount = stripe.Int64(int64(unitAmount))
+		} else if unitAmountDecimal := option["unit_amount_decimal"].(float64); unitAmountDecimal != 0 {
+			params.UnitAmountDecimal = stripe.Float64(unitAmountDecimal)
+		}
+
+		if taxBehavior := option["tax_behavior"].(string); taxBehavior != "" {
+			params.TaxBehavior = stripe.String(taxBehavior)
+		}
+
+		for _, tier := range expandPriceTiers(option["tier"].([]interface{})) {
+			params.Tiers = append(params.Tiers, (*stripe.PriceCurrencyOptionsTierParams)(tier))
+		}
+
+		if customUnitAmount := option["custom_unit_amount"].([]interface{}); len(customUnitAmount) > 0 && customUnitAmount[0] != nil {
+			c := customUnitAmount[0].(map[string]interface{})
+			params.CustomUnitAmount = &stripe.PriceCurrencyOptionsCustomUnitAmountParams{
+				Enabled: stripe.Bool(true),
+			}
+			if minimum := c["minimum"].(int); minimum > 0 {
+				params.CustomUnitAmount.Minimum = stripe.Int64(int64(minimum))
+			}
+			if maximum := c["maximum"].(int); maximum > 0 {
+				params.CustomUnitAmount.Maximum = stripe.Int64(int64(maximum))
+			}
+			if preset := c["preset"].(int); preset > 0 {
+				params.CustomUnitAmount.Preset = stripe.Int64(int64(preset))
+			}
+		}
+
+		out[option["currency"].(string)] = params
+	}
+	return out
+}
+
+// flattenPriceCurrencyOptions flattens the price's additional currencies.
+// Stripe also returns the price's own currency, which is skipped, and the
+// decimal amounts Stripe derives from whole amounts are left out so that
+// they don't show up as a diff.
+func flattenPriceCurrencyOptions(price *stripe.Price) []interface{} {
+	out := []interface{}{}
+	for currency, option := range price.CurrencyOptions {
+		if currency == string(price.Currency) {
+			continue
+		}
+
+		tiers := make([]interface{}, len(option.Tiers))
+		for i, tier := range option.Tiers {
+			tiers[i] = map[string]interface{}{
+				"up_to":               tier.UpTo,
+				"up_to_inf":           tier.UpTo == 0,
+				"flat_amount":         tier.FlatAmount,
+				"flat_amount_decimal": derivedDecimal(tier.FlatAmount, tier.FlatAmountDecimal),
+				"unit_amount":         tier.UnitAmount,
+				"unit_amount_decimal": derivedDecimal(tier.UnitAmount, tier.UnitAmountDecimal),
+			}
+		}
+
+		var customUnitAmount []interface{}
+		if option.CustomUnitAmount != nil {
+			customUnitAmount = []interface{}{
+				map[string]interface{}{
+					"minimum": option.CustomUnitAmount.Minimum,
+					"maximum": option.CustomUnitAmount.Maximum,
+					"preset":  option.CustomUnitAmount.Preset,
+				},
+			}
+		}
+
+		taxBehavior := string(option.TaxBehavior)
+		if taxBehavior == "unspecified" {
+			taxBehavior = ""
+		}
+
+		out = append(out, map[string]interface{}{
+			"currency":            currency,
+			"unit_amount":         option.UnitAmount,
+			"unit_amount_decimal": derivedDecimal(option.UnitAmount, option.UnitAmountDecimal),
+			"tax_behavior":        taxBehavior,
+			"tier":                tiers,
+			"custom_unit_amount":  customUnitAmount,
+		})
+	}
+	return out
 }
@@ -0,0 +1,84 @@
+package stripe
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceStripePriceV0 is the schema of stripe_price before `recurring`
+// became a block. Only the attributes' types matter to decode old states.
+func resourceStripePriceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"price_id":            {Type: schema.TypeString, Optional: true, Computed: true},
+			"active":              {Type: schema.TypeBool, Optional: true},
+			"currency":            {Type: schema.TypeString, Required: true},
+			"metadata":            {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"nickname":            {Type: schema.TypeString, Optional: true},
+			"product":             {Type: schema.TypeString, Optional: true},
+			"recurring":           {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"unit_amount":         {Type: schema.TypeInt, Optional: true, Computed: true},
+			"unit_amount_decimal": {Type: schema.TypeFloat, Optional: true, Computed: true},
+			"billing_scheme":      {Type: schema.TypeString, Optional: true, Computed: true},
+			"created":             {Type: schema.TypeInt, Computed: true},
+			"livemode":            {Type: schema.TypeBool, Computed: true},
+			"tier": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"up_to":               {Type: schema.TypeInt, Optional: true},
+						"up_to_inf":           {Type: schema.TypeBool, Optional: true},
+						"flat_amount":         {Type: schema.TypeInt, Optional: true, Computed: true},
+						"flat_amount_decimal": {Type: schema.TypeFloat, Optional: true, Computed: true},
+						"unit_amount":         {Type: schema.TypeInt, Optional: true, Computed: true},
+						"unit_amount_decimal": {Type: schema.TypeFloat, Optional: true, Computed: true},
+					},
+				},
+			},
+			"tiers_mode":     {Type: schema.TypeString, Optional: true},
+			"on_destroy":     {Type: schema.TypeString, Optional: true},
+			"stripe_account": {Type: schema.TypeString, Optional: true, Computed: true},
+		},
+	}
+}
+
+// resourceStripePriceStateUpgradeV0 turns the `recurring` map of strings
+// into a `recurring` block.
+func resourceStripePriceStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	recurring, _ := rawState["recurring"].(map[string]interface{})
+	if len(recurring) == 0 {
+		rawState["recurring"] = []interface{}{}
+		return rawState, nil
+	}
+
+	block := map[string]interface{}{
+		"interval":          recurring["interval"],
+		"interval_count":    1,
+		"usage_type":        "licensed",
+		"aggregate_usage":   "",
+		"trial_period_days": 0,
+	}
+
+	if v, ok := recurring["interval_count"].(string); ok {
+		intervalCount, err := strconv.Atoi(v)
+		if err != nil {
+			log.Printf("[WARN] Ignoring invalid interval_count %q of price %v", v, rawState["id"])
+		} else {
+			block["interval_count"] = intervalCount
+		}
+	}
+
+	if v, ok := recurring["usage_type"].(string); ok && v != "" {
+		block["usage_type"] = v
+	}
+
+	if v, ok := recurring["aggregate_usage"].(string); ok {
+		block["aggregate_usage"] = v
+	}
+
+	rawState["recurring"] = []interface{}{block}
+	return rawState, nil
+}
@@ -0,0 +1,67 @@
+package stripe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceStripePriceStateUpgradeV0(t *testing.T) {
+	cases := map[string]struct {
+		recurring interface{}
+		expected  []interface{}
+	}{
+		"one-time price": {
+			recurring: nil,
+			expected:  []interface{}{},
+		},
+		"defaults": {
+			recurring: map[string]interface{}{
+				"interval": "month",
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"interval":          "month",
+					"interval_count":    1,
+					"usage_type":        "licensed",
+					"aggregate_usage":   "",
+					"trial_period_days": 0,
+				},
+			},
+		},
+		"metered": {
+			recurring: map[string]interface{}{
+				"interval":        "week",
+				"interval_count":  "2",
+				"usage_type":      "metered",
+				"aggregate_usage": "max",
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"interval":          "week",
+					"interval_count":    2,
+					"usage_type":        "metered",
+					"aggregate_usage":   "max",
+					"trial_period_days": 0,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rawState := map[string]interface{}{
+				"id":        "price_1",
+				"recurring": tc.recurring,
+			}
+
+			actual, err := resourceStripePriceStateUpgradeV0(rawState, nil)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual["recurring"], tc.expected) {
+				t.Fatalf("expected recurring to be %#v, got %#v", tc.expected, actual["recurring"])
+			}
+		})
+	}
+}
@@ -0,0 +1,870 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripePrice_basic(t *testing.T) {
+	var price stripe.Price
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePriceConfig("Monthly", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &price),
+					resource.TestCheckResourceAttrPair("stripe_price.test", "product", "stripe_product.test", "id"),
+					resource.TestCheckResourceAttr("stripe_price.test", "nickname", "Monthly"),
+					resource.TestCheckResourceAttr("stripe_price.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_price.test", "currency", "usd"),
+					resource.TestCheckResourceAttr("stripe_price.test", "unit_amount", "1500"),
+					resource.TestCheckResourceAttr("stripe_price.test", "unit_amount_decimal", "1500"),
+					resource.TestCheckResourceAttr("stripe_price.test", "billing_scheme", "per_unit"),
+					resource.TestCheckResourceAttr("stripe_price.test", "metadata.sku", "basic-monthly"),
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.#", "1"),
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.0.interval", "month"),
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.0.interval_count", "1"),
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.0.usage_type", "licensed"),
+					resource.TestCheckResourceAttrSet("stripe_price.test", "created"),
+				),
+			},
+			{
+				Config: testAccStripePriceConfig("Monthly (archived)", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &price),
+					resource.TestCheckResourceAttr("stripe_price.test", "nickname", "Monthly (archived)"),
+					resource.TestCheckResourceAttr("stripe_price.test", "active", "false"),
+				),
+			},
+			{
+				ResourceName:      "stripe_price.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_recurringMetered(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Metered"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 10
+
+  recurring {
+    interval          = "week"
+    interval_count    = 2
+    usage_type        = "metered"
+    trial_period_days = 7
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.0.interval", "week"),
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.0.interval_count", "2"),
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.0.usage_type", "metered"),
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.0.aggregate_usage", "sum"),
+					resource.TestCheckResourceAttr("stripe_price.test", "recurring.0.trial_period_days", "7"),
+				),
+			},
+			{
+				ResourceName:      "stripe_price.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_recurringInvalidInterval(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_price" "test" {
+  currency    = "usd"
+  unit_amount = 10
+
+  recurring {
+    interval = "fortnight"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`expected recurring.0.interval to be one of`),
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_tiers(t *testing.T) {
+	var price stripe.Price
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePriceConfigTiers,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &price),
+					resource.TestCheckResourceAttr("stripe_price.test", "billing_scheme", "tiered"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tiers_mode", "graduated"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.#", "2"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.0.up_to", "10"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.0.up_to_inf", "false"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.0.unit_amount", "1000"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.0.flat_amount", "500"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.1.up_to", "0"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.1.up_to_inf", "true"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.1.unit_amount_decimal", "750.5"),
+				),
+			},
+			{
+				ResourceName:      "stripe_price.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Tiers edited outside of Terraform show up as drift.
+				PreConfig: func() {
+					testAccStripeMock.Put("prices", price.ID, map[string]interface{}{
+						"tiers": []interface{}{
+							map[string]interface{}{"up_to": int64(20), "unit_amount": int64(900), "unit_amount_decimal": "900"},
+							map[string]interface{}{"up_to": nil, "unit_amount": int64(700), "unit_amount_decimal": "700"},
+						},
+					})
+				},
+				Config:             testAccStripePriceConfigTiers,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_freeTier(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Freemium"
+}
+
+resource "stripe_price" "test" {
+  product        = stripe_product.test.id
+  currency       = "usd"
+  billing_scheme = "tiered"
+  tiers_mode     = "graduated"
+
+  recurring {
+    interval = "month"
+  }
+
+  tier {
+    up_to       = 5
+    unit_amount = 0
+  }
+
+  tier {
+    up_to_inf   = true
+    unit_amount = 1000
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.#", "2"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.0.unit_amount", "0"),
+					resource.TestCheckResourceAttr("stripe_price.test", "tier.1.unit_amount", "1000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_currencyOptionsTiers(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Freemium"
+}
+
+resource "stripe_price" "test" {
+  product        = stripe_product.test.id
+  currency       = "usd"
+  billing_scheme = "tiered"
+  tiers_mode     = "graduated"
+
+  recurring {
+    interval = "month"
+  }
+
+  tier {
+    up_to       = 5
+    unit_amount = 0
+  }
+
+  tier {
+    up_to_inf   = true
+    unit_amount = 1000
+  }
+
+  currency_options {
+    currency = "eur"
+
+    tier {
+      up_to       = 5
+      unit_amount = 0
+    }
+
+    tier {
+      up_to_inf   = true
+      flat_amount = 100
+      unit_amount = 900
+    }
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_price.test", "currency_options.#", "1"),
+					testAccCheckStripePriceCurrencyOptionTiers("stripe_price.test", "eur", 2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_currencyOptions(t *testing.T) {
+	var price stripe.Price
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Worldwide"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1000
+
+  currency_options {
+    currency     = "eur"
+    unit_amount  = 900
+    tax_behavior = "exclusive"
+  }
+
+  currency_options {
+    currency            = "gbp"
+    unit_amount_decimal = 850.5
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &price),
+					resource.TestCheckResourceAttr("stripe_price.test", "currency_options.#", "2"),
+					testAccCheckStripePriceCurrencyOptions(&price, map[string]float64{"usd": 1000, "eur": 900, "gbp": 850.5}),
+				),
+			},
+			{
+				ResourceName:      "stripe_price.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Worldwide"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1000
+
+  currency_options {
+    currency     = "eur"
+    unit_amount  = 950
+    tax_behavior = "exclusive"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &price),
+					resource.TestCheckResourceAttr("stripe_price.test", "currency_options.#", "1"),
+					testAccCheckStripePriceCurrencyOptions(&price, map[string]float64{"usd": 1000, "eur": 950}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStripePriceCurrencyOptions(price *stripe.Price, expected map[string]float64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		params := &stripe.PriceParams{}
+		params.AddExpand("currency_options")
+		found, err := client.Prices.Get(price.ID, params)
+		if err != nil {
+			return err
+		}
+
+		if len(found.CurrencyOptions) != len(expected) {
+			return fmt.Errorf("expected %d currency options, got %d", len(expected), len(found.CurrencyOptions))
+		}
+		for currency, amount := range expected {
+			option, ok := found.CurrencyOptions[currency]
+			if !ok {
+				return fmt.Errorf("missing %s currency option", currency)
+			}
+			if option.UnitAmountDecimal != amount {
+				return fmt.Errorf("expected %s unit amount to be %v, got %v", currency, amount, option.UnitAmountDecimal)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccStripePrice_taxBehavior(t *testing.T) {
+	var unspecified, exclusive, inclusive stripe.Price
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePriceConfigTaxBehavior(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &unspecified),
+					resource.TestCheckResourceAttr("stripe_price.test", "tax_behavior", "unspecified"),
+				),
+			},
+			{
+				// Setting the tax behavior for the first time updates the price.
+				Config: testAccStripePriceConfigTaxBehavior("exclusive"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &exclusive),
+					resource.TestCheckResourceAttr("stripe_price.test", "tax_behavior", "exclusive"),
+					testAccCheckStripePriceReplaced(&unspecified, &exclusive, false),
+				),
+			},
+			{
+				// Changing it afterwards replaces the price.
+				Config: testAccStripePriceConfigTaxBehavior("inclusive"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &inclusive),
+					resource.TestCheckResourceAttr("stripe_price.test", "tax_behavior", "inclusive"),
+					testAccCheckStripePriceReplaced(&exclusive, &inclusive, true),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStripePriceReplaced(before, after *stripe.Price, replaced bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if (before.ID != after.ID) != replaced {
+			return fmt.Errorf("expected price %s to be replaced: %t, got %s", before.ID, replaced, after.ID)
+		}
+		return nil
+	}
+}
+
+func TestAccStripePrice_customUnitAmount(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePriceConfigCustomUnitAmount(500, 10000, 2000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_price.test", "custom_unit_amount.#", "1"),
+					resource.TestCheckResourceAttr("stripe_price.test", "custom_unit_amount.0.enabled", "true"),
+					resource.TestCheckResourceAttr("stripe_price.test", "custom_unit_amount.0.minimum", "500"),
+					resource.TestCheckResourceAttr("stripe_price.test", "custom_unit_amount.0.maximum", "10000"),
+					resource.TestCheckResourceAttr("stripe_price.test", "custom_unit_amount.0.preset", "2000"),
+					resource.TestCheckResourceAttr("stripe_price.test", "unit_amount", "0"),
+				),
+			},
+			{
+				ResourceName:      "stripe_price.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_customUnitAmountInvalidPreset(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccStripePriceConfigCustomUnitAmount(500, 1000, 2000),
+				ExpectError: regexp.MustCompile(`custom_unit_amount.0.preset \(2000\) must be between`),
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_transformQuantity(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Seats"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 5000
+
+  recurring {
+    interval = "month"
+  }
+
+  transform_quantity {
+    divide_by = 10
+    round     = "up"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_price.test", "transform_quantity.#", "1"),
+					resource.TestCheckResourceAttr("stripe_price.test", "transform_quantity.0.divide_by", "10"),
+					resource.TestCheckResourceAttr("stripe_price.test", "transform_quantity.0.round", "up"),
+				),
+			},
+			{
+				ResourceName:      "stripe_price.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_lookupKey(t *testing.T) {
+	var before, after stripe.Price
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePriceConfigLookupKey(1000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &before),
+					resource.TestCheckResourceAttr("stripe_price.test", "lookup_key", "acceptance_pro_monthly"),
+					testAccCheckStripePriceLookupKey("acceptance_pro_monthly", &before),
+				),
+			},
+			{
+				// Replacing the price moves the lookup key to the new one.
+				Config: testAccStripePriceConfigLookupKey(1200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &after),
+					resource.TestCheckResourceAttr("stripe_price.test", "unit_amount", "1200"),
+					testAccCheckStripePriceLookupKey("acceptance_pro_monthly", &after),
+				),
+			},
+			{
+				ResourceName:            "stripe_price.test",
+				ImportState:             true,
+				ImportStateId:           "lookup_key:acceptance_pro_monthly",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"transfer_lookup_key"},
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_lookupKeyTaken(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Taken"
+  type = "service"
+}
+
+resource "stripe_price" "first" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1000
+  lookup_key  = "acceptance_taken"
+}
+
+resource "stripe_price" "second" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1000
+  lookup_key  = stripe_price.first.lookup_key
+}
+`,
+				ExpectError: regexp.MustCompile(`already uses that lookup key`),
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_importLookupKeyNotFound(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_price" "test" {
+  currency    = "usd"
+  unit_amount = 1000
+}
+`,
+				ResourceName:  "stripe_price.test",
+				ImportState:   true,
+				ImportStateId: "lookup_key:acceptance_missing",
+				ExpectError:   regexp.MustCompile(`expected exactly one price with lookup key "acceptance_missing", found 0`),
+			},
+		},
+	})
+}
+
+// testAccCheckStripePriceLookupKey checks that the lookup key resolves to the
+// given price, and to this price only.
+func testAccCheckStripePriceLookupKey(lookupKey string, price *stripe.Price) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		params := &stripe.PriceListParams{
+			LookupKeys: stripe.StringSlice([]string{lookupKey}),
+		}
+
+		var ids []string
+		i := client.Prices.List(params)
+		for i.Next() {
+			ids = append(ids, i.Price().ID)
+		}
+		if err := i.Err(); err != nil {
+			return err
+		}
+
+		if len(ids) != 1 || ids[0] != price.ID {
+			return fmt.Errorf("expected lookup key %s to resolve to %s only, got %v", lookupKey, price.ID, ids)
+		}
+		return nil
+	}
+}
+
+func TestAccStripePrice_onDestroy(t *testing.T) {
+	var price stripe.Price
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePriceForgotten(&price),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePriceConfigOnDestroy("error"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &price),
+					resource.TestCheckResourceAttr("stripe_price.test", "on_destroy", "error"),
+				),
+			},
+			{
+				Config: `
+resource "stripe_product" "test" {
+  name = "Priced"
+  type = "service"
+}
+`,
+				ExpectError: regexp.MustCompile(`Stripe doesn't allow deleting prices via the API`),
+			},
+			{
+				Config: testAccStripePriceConfigOnDestroy("forget"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_price.test", "on_destroy", "forget"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripePrice_onDestroyArchive(t *testing.T) {
+	var price stripe.Price
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePriceArchived(&price),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePriceConfigOnDestroy("archive"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceExists("stripe_price.test", &price),
+				),
+			},
+			{
+				// Replacing the price archives the previous one.
+				Config: testAccStripePriceConfigOnDestroyAmount("archive", 2500),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePriceArchived(&price),
+					resource.TestCheckResourceAttr("stripe_price.test", "unit_amount", "2500"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStripePriceArchived(price *stripe.Price) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.Prices.Get(price.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if found.Active {
+			return fmt.Errorf("price %s should have been archived", price.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckStripePriceForgotten(price *stripe.Price) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.Prices.Get(price.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if !found.Active {
+			return fmt.Errorf("price %s should have been left untouched", price.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckStripePriceExists(n string, price *stripe.Price) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.Prices.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*price = *found
+		return nil
+	}
+}
+
+func testAccStripePriceConfig(nickname string, active bool) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Priced"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  nickname    = "%s"
+  active      = %t
+  currency    = "usd"
+  unit_amount = 1500
+
+  recurring {
+    interval       = "month"
+    interval_count = 1
+  }
+
+  metadata = {
+    sku = "basic-monthly"
+  }
+}
+`, nickname, active)
+}
+
+func testAccStripePriceConfigOnDestroy(onDestroy string) string {
+	return testAccStripePriceConfigOnDestroyAmount(onDestroy, 1500)
+}
+
+func testAccStripePriceConfigOnDestroyAmount(onDestroy string, unitAmount int) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Priced"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = %d
+  on_destroy  = "%s"
+}
+`, unitAmount, onDestroy)
+}
+
+const testAccStripePriceConfigTiers = `
+resource "stripe_product" "test" {
+  name = "Tiered"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product        = stripe_product.test.id
+  currency       = "usd"
+  billing_scheme = "tiered"
+  tiers_mode     = "graduated"
+
+  recurring {
+    interval = "month"
+  }
+
+  tier {
+    up_to       = 10
+    unit_amount = 1000
+    flat_amount = 500
+  }
+
+  tier {
+    up_to_inf           = true
+    unit_amount_decimal = 750.5
+  }
+}
+`
+
+func testAccStripePriceConfigLookupKey(unitAmount int) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Looked up"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product             = stripe_product.test.id
+  currency            = "usd"
+  unit_amount         = %d
+  lookup_key          = "acceptance_pro_monthly"
+  transfer_lookup_key = true
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, unitAmount)
+}
+
+func testAccStripePriceConfigTaxBehavior(taxBehavior string) string {
+	var attribute string
+	if taxBehavior != "" {
+		attribute = fmt.Sprintf("tax_behavior = %q", taxBehavior)
+	}
+
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Taxed"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1000
+  %s
+}
+`, attribute)
+}
+
+func testAccStripePriceConfigCustomUnitAmount(minimum, maximum, preset int) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Donation"
+  type = "service"
+}
+
+resource "stripe_price" "test" {
+  product  = stripe_product.test.id
+  currency = "usd"
+
+  custom_unit_amount {
+    minimum = %d
+    maximum = %d
+    preset  = %d
+  }
+}
+`, minimum, maximum, preset)
+}
+
+// testAccCheckStripePriceCurrencyOptionTiers checks how many tiers a
+// currency of the price has in Stripe.
+func testAccCheckStripePriceCurrencyOptionTiers(n, currency string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		params := &stripe.PriceParams{}
+		params.AddExpand("currency_options")
+		price, err := client.Prices.Get(rs.Primary.ID, params)
+		if err != nil {
+			return err
+		}
+
+		option, ok := price.CurrencyOptions[currency]
+		if !ok {
+			return fmt.Errorf("expected price %s to have currency %s", price.ID, currency)
+		}
+		if len(option.Tiers) != count {
+			return fmt.Errorf("expected %s of price %s to have %d tiers, got %d", currency, price.ID, count, len(option.Tiers))
+		}
+		return nil
+	}
+}
@@ -3,8 +3,8 @@ package stripe
 import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 
+	"encoding/json"
 	"fmt"
 	"log"
 )
@@ -36,7 +36,8 @@ func resourceStripeProduct() *schema.Resource {
 			},
 			"type": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Default:  "service",
 			},
 			"active": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -63,12 +64,91 @@ func resourceStripeProduct() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"images": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				MaxItems: 8,
+			},
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"shippable": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true, // goods are shippable by default
+			},
+			"package_dimensions": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Inches
+						"height": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+						"length": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+						"width": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+						// Ounces
+						"weight": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+					},
+				},
+			},
+			"tax_code": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// The price has to belong to the product, so it can only be set
+			// once both exist.
+			"default_price": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// Marketing features, listed in pricing tables.
+			"feature": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 15,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
 		},
 	}
 }
 
 func resourceStripeProductCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
 	productName := d.Get("name").(string)
 	productType := d.Get("type").(string)
 	productStatementDescriptor := d.Get("statement_descriptor").(string)
@@ -94,9 +174,7 @@ func resourceStripeProductCreate(d *schema.ResourceData, m interface{}) error {
 		params.ID = stripe.String(productID.(string))
 	}
 
-	if active, ok := d.GetOk("active"); ok {
-		params.Active = stripe.Bool(active.(bool))
-	}
+	params.Active = stripe.Bool(d.Get("active").(bool))
 
 	params.Attributes = expandAttributes(d)
 
@@ -110,26 +188,79 @@ func resourceStripeProductCreate(d *schema.ResourceData, m interface{}) error {
 		params.UnitLabel = stripe.String(productUnitLabel)
 	}
 
-	product, err := client.Products.New(params)
+	if description, ok := d.GetOk("description"); ok {
+		params.Description = stripe.String(description.(string))
+	}
+
+	params.Images = expandStringList(d, "images")
+
+	if url, ok := d.GetOk("url"); ok {
+		params.URL = stripe.String(url.(string))
+	}
+
+	if shippable, ok := d.GetOkExists("shippable"); ok {
+		params.Shippable = stripe.Bool(shippable.(bool))
+	}
+
+	if packageDimensions, ok := d.GetOk("package_dimensions"); ok {
+		params.PackageDimensions = expandPackageDimensions(packageDimensions.([]interface{}))
+	}
+
+	if taxCode, ok := d.GetOk("tax_code"); ok {
+		params.TaxCode = stripe.String(taxCode.(string))
+	}
+
+	addProductFeatures(&params.Params, d.Get("feature").([]interface{}))
+
+	setStripeAccount(params, account)
+	var product *stripe.Product
+	err := createIdempotently(client, "products", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		product, err = client.Products.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return product.ID, product.LastResponse, nil
+	})
 
 	if err != nil {
 		return err
 	}
 
 	log.Printf("[INFO] Created Stripe product: %s", productName)
-	d.SetId(product.ID)
+	d.SetId(stripeResourceID(account, product.ID))
+
+	if defaultPrice, ok := d.GetOk("default_price"); ok {
+		params := &stripe.ProductParams{
+			DefaultPrice: stripe.String(defaultPrice.(string)),
+		}
+		setStripeAccount(params, account)
+		if _, err := client.Products.Update(product.ID, params); err != nil {
+			return err
+		}
+	}
 
 	return resourceStripeProductRead(d, m)
 }
 
 func resourceStripeProductRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	product, err := client.Products.Get(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.ProductParams{}
+	setStripeAccount(params, account)
+	product, err := client.Products.Get(id, params)
 
 	if err != nil {
 		return err
 	}
 
+	d.Set("stripe_account", account)
+	return flattenProduct(d, product, product.LastResponse)
+}
+
+// flattenProduct sets the product's attributes. response is the one the
+// product was read from, see flattenProductFeatures.
+func flattenProduct(d *schema.ResourceData, product *stripe.Product, response *stripe.APIResponse) error {
 	d.Set("product_id", product.ID)
 	d.Set("name", product.Name)
 	d.Set("type", product.Type)
@@ -138,13 +269,38 @@ func resourceStripeProductRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("metadata", product.Metadata)
 	d.Set("statement_descriptor", product.StatementDescriptor)
 	d.Set("unit_label", product.UnitLabel)
+	d.Set("description", product.Description)
+	d.Set("images", product.Images)
+	d.Set("url", product.URL)
+	d.Set("shippable", product.Shippable)
+	d.Set("package_dimensions", flattenPackageDimensions(product.PackageDimensions))
+
+	if product.TaxCode != nil {
+		d.Set("tax_code", product.TaxCode.ID)
+	} else {
+		d.Set("tax_code", "")
+	}
+
+	if product.DefaultPrice != nil {
+		d.Set("default_price", product.DefaultPrice.ID)
+	} else {
+		d.Set("default_price", "")
+	}
+
+	features, err := flattenProductFeatures(response, product.ID)
+	if err != nil {
+		return err
+	}
+	d.Set("feature", features)
 
 	return nil
 }
 
 func resourceStripeProductUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
 	params := stripe.ProductParams{}
+	setStripeAccount(&params, account)
 
 	if d.HasChange("name") {
 		params.Name = stripe.String(d.Get("name").(string))
@@ -174,7 +330,51 @@ func resourceStripeProductUpdate(d *schema.ResourceData, m interface{}) error {
 		params.UnitLabel = stripe.String(d.Get("unit_label").(string))
 	}
 
-	_, err := client.Products.Update(d.Id(), &params)
+	if d.HasChange("description") {
+		params.Description = stripe.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("images") {
+		if images := expandStringList(d, "images"); len(images) > 0 {
+			params.Images = images
+		} else {
+			params.AddExtra("images", "")
+		}
+	}
+
+	if d.HasChange("url") {
+		params.URL = stripe.String(d.Get("url").(string))
+	}
+
+	if d.HasChange("shippable") {
+		params.Shippable = stripe.Bool(d.Get("shippable").(bool))
+	}
+
+	if d.HasChange("package_dimensions") {
+		if packageDimensions := d.Get("package_dimensions").([]interface{}); len(packageDimensions) > 0 {
+			params.PackageDimensions = expandPackageDimensions(packageDimensions)
+		} else {
+			params.AddExtra("package_dimensions", "")
+		}
+	}
+
+	if d.HasChange("tax_code") {
+		params.TaxCode = stripe.String(d.Get("tax_code").(string))
+	}
+
+	if d.HasChange("default_price") {
+		params.DefaultPrice = stripe.String(d.Get("default_price").(string))
+	}
+
+	if d.HasChange("feature") {
+		if features := d.Get("feature").([]interface{}); len(features) > 0 {
+			addProductFeatures(&params.Params, features)
+		} else {
+			params.AddExtra("features", "")
+		}
+	}
+
+	_, err := client.Products.Update(id, &params)
 
 	if err != nil {
 		return err
@@ -183,9 +383,86 @@ func resourceStripeProductUpdate(d *schema.ResourceData, m interface{}) error {
 	return resourceStripeProductRead(d, m)
 }
 
+func expandPackageDimensions(in []interface{}) *stripe.PackageDimensionsParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	dimensions := in[0].(map[string]interface{})
+	return &stripe.PackageDimensionsParams{
+		Height: stripe.Float64(dimensions["height"].(float64)),
+		Length: stripe.Float64(dimensions["length"].(float64)),
+		Weight: stripe.Float64(dimensions["weight"].(float64)),
+		Width:  stripe.Float64(dimensions["width"].(float64)),
+	}
+}
+
+func flattenPackageDimensions(dimensions *stripe.PackageDimensions) []interface{} {
+	if dimensions == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"height": dimensions.Height,
+			"length": dimensions.Length,
+			"weight": dimensions.Weight,
+			"width":  dimensions.Width,
+		},
+	}
+}
+
+// The Stripe SDK doesn't know about marketing features yet, they're sent as
+// extra parameters and read from the raw response.
+func addProductFeatures(params *stripe.Params, in []interface{}) {
+	for i, v := range in {
+		feature := v.(map[string]interface{})
+		params.AddExtra(fmt.Sprintf("features[%d][name]", i), feature["name"].(string))
+	}
+}
+
+// flattenProductFeatures reads the features of the product with the given ID
+// from the response it was read from: the product itself, or a page of
+// products when it was listed.
+func flattenProductFeatures(response *stripe.APIResponse, id string) ([]interface{}, error) {
+	if response == nil {
+		return nil, nil
+	}
+
+	type rawProduct struct {
+		ID       string `json:"id"`
+		Features []struct {
+			Name string `json:"name"`
+		} `json:"features"`
+	}
+	raw := struct {
+		rawProduct
+		Data []rawProduct `json:"data"`
+	}{}
+	if err := json.Unmarshal(response.RawJSON, &raw); err != nil {
+		return nil, err
+	}
+
+	product := raw.rawProduct
+	for _, p := range raw.Data {
+		if p.ID == id {
+			product = p
+		}
+	}
+
+	out := make([]interface{}, len(product.Features))
+	for i, feature := range product.Features {
+		out[i] = map[string]interface{}{
+			"name": feature.Name,
+		}
+	}
+	return out, nil
+}
+
 func resourceStripeProductDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	_, err := client.Products.Del(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.ProductParams{}
+	setStripeAccount(params, account)
+	_, err := client.Products.Del(id, params)
 
 	if err == nil {
 		d.SetId("")
@@ -0,0 +1,331 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeProduct_basic(t *testing.T) {
+	var product stripe.Product
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeProductDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeProductConfig("Basic", "per seat"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeProductExists("stripe_product.test", &product),
+					resource.TestCheckResourceAttr("stripe_product.test", "name", "Basic"),
+					resource.TestCheckResourceAttr("stripe_product.test", "type", "service"),
+					resource.TestCheckResourceAttr("stripe_product.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_product.test", "unit_label", "per seat"),
+					resource.TestCheckResourceAttr("stripe_product.test", "statement_descriptor", "ACME BASIC"),
+					resource.TestCheckResourceAttr("stripe_product.test", "metadata.tier", "basic"),
+				),
+			},
+			{
+				Config: testAccStripeProductConfig("Premium", "per user"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeProductExists("stripe_product.test", &product),
+					resource.TestCheckResourceAttr("stripe_product.test", "name", "Premium"),
+					resource.TestCheckResourceAttr("stripe_product.test", "unit_label", "per user"),
+				),
+			},
+			{
+				ResourceName:      "stripe_product.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripeProduct_catalog(t *testing.T) {
+	var before, after stripe.Product
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeProductDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeProductCatalogConfig(`
+  description = "A sturdy box"
+  images      = ["https://acme.example/box.png", "https://acme.example/box-open.png"]
+  url         = "https://acme.example/box"
+  shippable   = true
+  tax_code    = "txcd_99999999"
+
+  package_dimensions {
+    height = 10.5
+    length = 20
+    width  = 15.25
+    weight = 32
+  }
+
+  feature {
+    name = "Waterproof"
+  }
+
+  feature {
+    name = "Recyclable"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeProductExists("stripe_product.test", &before),
+					resource.TestCheckResourceAttr("stripe_product.test", "type", "service"),
+					resource.TestCheckResourceAttr("stripe_product.test", "description", "A sturdy box"),
+					resource.TestCheckResourceAttr("stripe_product.test", "images.#", "2"),
+					resource.TestCheckResourceAttr("stripe_product.test", "images.1", "https://acme.example/box-open.png"),
+					resource.TestCheckResourceAttr("stripe_product.test", "url", "https://acme.example/box"),
+					resource.TestCheckResourceAttr("stripe_product.test", "shippable", "true"),
+					resource.TestCheckResourceAttr("stripe_product.test", "tax_code", "txcd_99999999"),
+					resource.TestCheckResourceAttr("stripe_product.test", "package_dimensions.0.height", "10.5"),
+					resource.TestCheckResourceAttr("stripe_product.test", "package_dimensions.0.width", "15.25"),
+					resource.TestCheckResourceAttr("stripe_product.test", "package_dimensions.0.weight", "32"),
+					resource.TestCheckResourceAttr("stripe_product.test", "feature.#", "2"),
+					resource.TestCheckResourceAttr("stripe_product.test", "feature.1.name", "Recyclable"),
+					resource.TestCheckResourceAttr("stripe_product.test", "default_price", ""),
+				),
+			},
+			{
+				Config: testAccStripeProductCatalogConfig(`
+  description = "A sturdier box"
+  images      = ["https://acme.example/box-v2.png"]
+  shippable   = false
+`) + testAccStripeProductPriceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeProductExists("stripe_product.test", &after),
+					testAccCheckStripeProductNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr("stripe_product.test", "description", "A sturdier box"),
+					resource.TestCheckResourceAttr("stripe_product.test", "images.#", "1"),
+					resource.TestCheckResourceAttr("stripe_product.test", "url", ""),
+					resource.TestCheckResourceAttr("stripe_product.test", "shippable", "false"),
+					resource.TestCheckResourceAttr("stripe_product.test", "tax_code", ""),
+					resource.TestCheckResourceAttr("stripe_product.test", "package_dimensions.#", "0"),
+					resource.TestCheckResourceAttr("stripe_product.test", "feature.#", "0"),
+				),
+			},
+			{
+				// The price references the product, so the default price is
+				// looked up once it exists.
+				Config: testAccStripeProductCatalogConfig(`
+  description   = "A sturdier box"
+  images        = ["https://acme.example/box-v2.png"]
+  shippable     = false
+  default_price = data.stripe_price.test.id
+`) + testAccStripeProductPriceConfig + `
+data "stripe_price" "test" {
+  lookup_key = "box_standard"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("stripe_product.test", "default_price", "stripe_price.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "stripe_product.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripeProduct_productID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeProductDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  product_id = "prod_acceptance_custom_id"
+  name       = "Custom ID"
+  type       = "good"
+  attributes = ["size", "color"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_product.test", "id", "prod_acceptance_custom_id"),
+					resource.TestCheckResourceAttr("stripe_product.test", "type", "good"),
+					resource.TestCheckResourceAttr("stripe_product.test", "attributes.#", "2"),
+					resource.TestCheckResourceAttr("stripe_product.test", "attributes.1", "color"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripeProduct_stripeAccount(t *testing.T) {
+	var product stripe.Product
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeProductDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_product" "test" {
+  stripe_account = "acct_acceptance"
+  name           = "Connected"
+  type           = "service"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeProductExists("stripe_product.test", &product),
+					resource.TestCheckResourceAttr("stripe_product.test", "stripe_account", "acct_acceptance"),
+					resource.TestMatchResourceAttr("stripe_product.test", "id", regexp.MustCompile(`^acct_acceptance/prod_`)),
+					testAccCheckStripeProductNotOnPlatform(&product),
+				),
+			},
+			{
+				ResourceName:      "stripe_product.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripeProduct_providerStripeAccount(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeProductDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "stripe" {
+  stripe_account = "acct_acceptance"
+}
+
+resource "stripe_product" "test" {
+  name = "Connected through the provider"
+  type = "service"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_product.test", "stripe_account", "acct_acceptance"),
+					resource.TestMatchResourceAttr("stripe_product.test", "id", regexp.MustCompile(`^acct_acceptance/prod_`)),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandPackageDimensions(t *testing.T) {
+	for _, in := range [][]interface{}{nil, {}, {nil}} {
+		if dimensions := expandPackageDimensions(in); dimensions != nil {
+			t.Errorf("expected no package dimensions for %#v, got %#v", in, dimensions)
+		}
+	}
+
+	dimensions := expandPackageDimensions([]interface{}{
+		map[string]interface{}{"height": 1.5, "length": 2.0, "weight": 3.25, "width": 4.0},
+	})
+	if dimensions == nil || *dimensions.Height != 1.5 || *dimensions.Weight != 3.25 {
+		t.Fatalf("unexpected package dimensions %#v", dimensions)
+	}
+}
+
+func testAccCheckStripeProductNotOnPlatform(product *stripe.Product) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		if _, err := client.Products.Get(product.ID, nil); err == nil {
+			return fmt.Errorf("product %s should only exist on the connected account", product.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckStripeProductExists(n string, product *stripe.Product) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		account, id := parseStripeResourceID(rs.Primary.ID)
+		params := &stripe.ProductParams{}
+		setStripeAccount(params, account)
+		found, err := client.Products.Get(id, params)
+		if err != nil {
+			return err
+		}
+
+		*product = *found
+		return nil
+	}
+}
+
+func testAccCheckStripeProductNotRecreated(before, after *stripe.Product) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID != after.ID {
+			return fmt.Errorf("expected product %s to be updated, it was replaced by %s", before.ID, after.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckStripeProductDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_product" {
+			continue
+		}
+
+		account, id := parseStripeResourceID(rs.Primary.ID)
+		params := &stripe.ProductParams{}
+		setStripeAccount(params, account)
+		if _, err := client.Products.Get(id, params); err == nil {
+			return fmt.Errorf("product %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStripeProductConfig(name, unitLabel string) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name                 = "%s"
+  type                 = "service"
+  unit_label           = "%s"
+  statement_descriptor = "ACME BASIC"
+
+  metadata = {
+    tier = "basic"
+  }
+}
+`, name, unitLabel)
+}
+
+func testAccStripeProductCatalogConfig(settings string) string {
+	return fmt.Sprintf(`
+resource "stripe_product" "test" {
+  name = "Box"
+%s
+}
+`, settings)
+}
+
+const testAccStripeProductPriceConfig = `
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1500
+  lookup_key  = "box_standard"
+}
+`
@@ -0,0 +1,288 @@
+package stripe
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripePromotionCode() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStripePromotionCodeCreate,
+		Read:   resourceStripePromotionCodeRead,
+		Update: resourceStripePromotionCodeUpdate,
+		Delete: resourceStripePromotionCodeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"code": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true, // generated by Stripe when left blank
+				ForceNew: true,
+			},
+			"coupon": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"customer": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"expires_at": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+			},
+			"max_redemptions": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"restrictions": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"first_time_transaction": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+						"minimum_amount": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"minimum_amount_currency": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"created": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"times_redeemed": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
+		},
+	}
+}
+
+func resourceStripePromotionCodeCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.PromotionCodeParams{
+		Coupon: stripe.String(d.Get("coupon").(string)),
+		Active: stripe.Bool(d.Get("active").(bool)),
+	}
+
+	if code, ok := d.GetOk("code"); ok {
+		params.Code = stripe.String(code.(string))
+	}
+
+	if customer, ok := d.GetOk("customer"); ok {
+		params.Customer = stripe.String(customer.(string))
+	}
+
+	if expiresAt, ok := d.GetOk("expires_at"); ok {
+		timestamp, err := expandTimestamp(expiresAt.(string))
+		if err != nil {
+			return fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", expiresAt)
+		}
+		params.ExpiresAt = stripe.Int64(timestamp)
+	}
+
+	if maxRedemptions, ok := d.GetOk("max_redemptions"); ok {
+		params.MaxRedemptions = stripe.Int64(int64(maxRedemptions.(int)))
+	}
+
+	if restrictions, ok := d.GetOk("restrictions"); ok {
+		params.Restrictions = expandPromotionCodeRestrictions(restrictions.([]interface{}))
+	}
+
+	params.Metadata = expandMetadata(d)
+
+	setStripeAccount(params, account)
+	var promotionCode *stripe.PromotionCode
+	err := createIdempotently(client, "promotion_codes", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		promotionCode, err = client.PromotionCodes.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return promotionCode.ID, promotionCode.LastResponse, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Create promotion code: %s (%s)", promotionCode.Code, promotionCode.ID)
+	d.SetId(stripeResourceID(account, promotionCode.ID))
+
+	return resourceStripePromotionCodeRead(d, m)
+}
+
+func expandPromotionCodeRestrictions(in []interface{}) *stripe.PromotionCodeRestrictionsParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	restrictions := in[0].(map[string]interface{})
+	params := &stripe.PromotionCodeRestrictionsParams{
+		FirstTimeTransaction: stripe.Bool(restrictions["first_time_transaction"].(bool)),
+	}
+
+	if minimumAmount := restrictions["minimum_amount"].(int); minimumAmount > 0 {
+		params.MinimumAmount = stripe.Int64(int64(minimumAmount))
+	}
+
+	if currency := restrictions["minimum_amount_currency"].(string); currency != "" {
+		params.MinimumAmountCurrency = stripe.String(currency)
+	}
+
+	return params
+}
+
+func resourceStripePromotionCodeRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.PromotionCodeParams{}
+	setStripeAccount(params, account)
+	promotionCode, err := client.PromotionCodes.Get(id, params)
+
+	if err != nil {
+		d.SetId("")
+	} else {
+		d.Set("stripe_account", account)
+		flattenPromotionCode(d, promotionCode)
+	}
+
+	return err
+}
+
+func flattenPromotionCode(d *schema.ResourceData, promotionCode *stripe.PromotionCode) {
+	d.Set("code", promotionCode.Code)
+	if promotionCode.Coupon != nil {
+		d.Set("coupon", promotionCode.Coupon.ID)
+	}
+	if promotionCode.Customer != nil {
+		d.Set("customer", promotionCode.Customer.ID)
+	} else {
+		d.Set("customer", "")
+	}
+	d.Set("expires_at", flattenTimestamp(promotionCode.ExpiresAt))
+	d.Set("max_redemptions", promotionCode.MaxRedemptions)
+	d.Set("restrictions", flattenPromotionCodeRestrictions(d, promotionCode.Restrictions))
+	d.Set("active", promotionCode.Active)
+	d.Set("metadata", promotionCode.Metadata)
+	d.Set("created", promotionCode.Created)
+	d.Set("livemode", promotionCode.Livemode)
+	d.Set("times_redeemed", promotionCode.TimesRedeemed)
+}
+
+func flattenPromotionCodeRestrictions(d *schema.ResourceData, restrictions *stripe.PromotionCodeRestrictions) []interface{} {
+	if restrictions == nil {
+		return nil
+	}
+
+	// Stripe always returns restrictions; leave them out when none are set
+	// and the block isn't configured, so that codes without restrictions
+	// don't show a diff.
+	empty := !restrictions.FirstTimeTransaction && restrictions.MinimumAmount == 0 && restrictions.MinimumAmountCurrency == ""
+	if empty && len(d.Get("restrictions").([]interface{})) == 0 {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"first_time_transaction":  restrictions.FirstTimeTransaction,
+			"minimum_amount":          restrictions.MinimumAmount,
+			"minimum_amount_currency": string(restrictions.MinimumAmountCurrency),
+		},
+	}
+}
+
+func resourceStripePromotionCodeUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := stripe.PromotionCodeParams{}
+	setStripeAccount(&params, account)
+
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(d.Get("active").(bool))
+	}
+
+	if d.HasChange("metadata") {
+		params.Metadata = expandMetadata(d)
+	}
+
+	_, err := client.PromotionCodes.Update(id, &params)
+
+	if err != nil {
+		return err
+	}
+
+	return resourceStripePromotionCodeRead(d, m)
+}
+
+// Stripe doesn't allow deleting promotion codes: they are deactivated instead,
+// so that they can't be redeemed anymore.
+func resourceStripePromotionCodeDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.PromotionCodeParams{
+		Active: stripe.Bool(false),
+	}
+	setStripeAccount(params, account)
+	_, err := client.PromotionCodes.Update(id, params)
+
+	if err == nil {
+		log.Printf("[INFO] Deactivated promotion code: %s", d.Id())
+		d.SetId("")
+	}
+
+	return err
+}
@@ -0,0 +1,209 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripePromotionCode_basic(t *testing.T) {
+	var promotionCode stripe.PromotionCode
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePromotionCodeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripePromotionCodeConfig(true, "spring"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePromotionCodeExists("stripe_promotion_code.test", &promotionCode),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "code", "SPRING10"),
+					resource.TestCheckResourceAttrPair("stripe_promotion_code.test", "coupon", "stripe_coupon.test", "code"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "expires_at", "2030-01-01T08:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "max_redemptions", "50"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "restrictions.#", "1"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "restrictions.0.first_time_transaction", "true"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "restrictions.0.minimum_amount", "5000"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "restrictions.0.minimum_amount_currency", "usd"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "metadata.campaign", "spring"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "times_redeemed", "0"),
+				),
+			},
+			{
+				Config: testAccStripePromotionCodeConfig(false, "summer"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripePromotionCodeExists("stripe_promotion_code.test", &promotionCode),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "active", "false"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "metadata.campaign", "summer"),
+					testAccCheckStripePromotionCodeActive(&promotionCode, false),
+				),
+			},
+			{
+				ResourceName:      "stripe_promotion_code.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStripePromotionCode_generatedCode(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePromotionCodeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_coupon" "test" {
+  code        = "GENERATED"
+  duration    = "forever"
+  percent_off = 5
+}
+
+resource "stripe_promotion_code" "test" {
+  coupon = stripe_coupon.test.code
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("stripe_promotion_code.test", "code", regexp.MustCompile(`^\w+$`)),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "restrictions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripePromotionCode_emptyRestrictions(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripePromotionCodeDestroy,
+		Steps: []resource.TestStep{
+			{
+				// A block that only sets defaults is read back as is, rather
+				// than replacing the code on every plan.
+				Config: `
+resource "stripe_coupon" "test" {
+  code        = "RETURNING"
+  duration    = "forever"
+  percent_off = 5
+}
+
+resource "stripe_promotion_code" "test" {
+  coupon = stripe_coupon.test.code
+
+  restrictions {
+    first_time_transaction = false
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "restrictions.#", "1"),
+					resource.TestCheckResourceAttr("stripe_promotion_code.test", "restrictions.0.first_time_transaction", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripePromotionCode_invalidExpiresAt(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_promotion_code" "test" {
+  coupon     = "NEVER"
+  expires_at = "next tuesday"
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid RFC3339 timestamp`),
+			},
+		},
+	})
+}
+
+func testAccCheckStripePromotionCodeExists(n string, promotionCode *stripe.PromotionCode) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.PromotionCodes.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*promotionCode = *found
+		return nil
+	}
+}
+
+func testAccCheckStripePromotionCodeActive(promotionCode *stripe.PromotionCode, active bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if promotionCode.Active != active {
+			return fmt.Errorf("expected promotion code %s to have active = %t", promotionCode.ID, active)
+		}
+		return nil
+	}
+}
+
+// Promotion codes can't be deleted, destroying them deactivates them.
+func testAccCheckStripePromotionCodeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_promotion_code" {
+			continue
+		}
+
+		promotionCode, err := client.PromotionCodes.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if promotionCode.Active {
+			return fmt.Errorf("promotion code %s is still active", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStripePromotionCodeConfig(active bool, campaign string) string {
+	return fmt.Sprintf(`
+resource "stripe_coupon" "test" {
+  code        = "SPRING"
+  duration    = "once"
+  percent_off = 10
+}
+
+resource "stripe_promotion_code" "test" {
+  code            = "SPRING10"
+  coupon          = stripe_coupon.test.code
+  expires_at      = "2030-01-01T00:00:00-08:00"
+  max_redemptions = 50
+  active          = %t
+
+  restrictions {
+    first_time_transaction  = true
+    minimum_amount          = 5000
+    minimum_amount_currency = "usd"
+  }
+
+  metadata = {
+    campaign = "%s"
+  }
+}
+`, active, campaign)
+}
@@ -0,0 +1,403 @@
+package stripe
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+var shippingRateDeliveryEstimateUnits = []string{"hour", "day", "business_day", "week", "month"}
+
+func resourceStripeShippingRate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStripeShippingRateCreate,
+		Read:   resourceStripeShippingRateRead,
+		Update: resourceStripeShippingRateUpdate,
+		Delete: resourceStripeShippingRateDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		// Like prices', a shipping rate's tax behavior can only be set once.
+		CustomizeDiff: customdiff.ForceNewIfChange("tax_behavior", func(old, new, meta interface{}) bool {
+			return old.(string) != "" && old.(string) != "unspecified"
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"display_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "fixed_amount",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"fixed_amount"}, false),
+			},
+			"fixed_amount": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"amount": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"currency": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"currency_options": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"currency": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"amount": &schema.Schema{
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"tax_behavior": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"inclusive", "exclusive"}, false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"delivery_estimate": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"minimum": shippingRateDeliveryEstimateBoundSchema(),
+						"maximum": shippingRateDeliveryEstimateBoundSchema(),
+					},
+				},
+			},
+			"tax_behavior": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true, // unspecified by default
+				ValidateFunc: validation.StringInSlice([]string{"inclusive", "exclusive", "unspecified"}, false),
+			},
+			"tax_code": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"created": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"on_destroy": onDestroySchema(),
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
+		},
+	}
+}
+
+func shippingRateDeliveryEstimateBoundSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"unit": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringInSlice(shippingRateDeliveryEstimateUnits, false),
+				},
+				"value": &schema.Schema{
+					Type:         schema.TypeInt,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func resourceStripeShippingRateCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.ShippingRateParams{
+		DisplayName: stripe.String(d.Get("display_name").(string)),
+		Type:        stripe.String(d.Get("type").(string)),
+		FixedAmount: expandShippingRateFixedAmount(d.Get("fixed_amount").([]interface{})),
+	}
+
+	params.Active = stripe.Bool(d.Get("active").(bool))
+
+	if deliveryEstimate, ok := d.GetOk("delivery_estimate"); ok {
+		params.DeliveryEstimate = expandShippingRateDeliveryEstimate(deliveryEstimate.([]interface{}))
+	}
+
+	if taxBehavior, ok := d.GetOk("tax_behavior"); ok {
+		params.TaxBehavior = stripe.String(taxBehavior.(string))
+	}
+
+	if taxCode, ok := d.GetOk("tax_code"); ok {
+		params.TaxCode = stripe.String(taxCode.(string))
+	}
+
+	params.Metadata = expandMetadata(d)
+
+	setStripeAccount(params, account)
+	var shippingRate *stripe.ShippingRate
+	err := createIdempotently(client, "shipping_rates", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		shippingRate, err = client.ShippingRates.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return shippingRate.ID, shippingRate.LastResponse, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Create shipping rate: %s (%s)", shippingRate.DisplayName, shippingRate.ID)
+	d.SetId(stripeResourceID(account, shippingRate.ID))
+
+	return resourceStripeShippingRateRead(d, m)
+}
+
+func expandShippingRateFixedAmount(in []interface{}) *stripe.ShippingRateFixedAmountParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	fixedAmount := in[0].(map[string]interface{})
+	params := &stripe.ShippingRateFixedAmountParams{
+		Amount:   stripe.Int64(int64(fixedAmount["amount"].(int))),
+		Currency: stripe.String(fixedAmount["currency"].(string)),
+	}
+	if currencyOptions := fixedAmount["currency_options"].(*schema.Set); currencyOptions.Len() > 0 {
+		params.CurrencyOptions = expandShippingRateCurrencyOptions(currencyOptions.List())
+	}
+	return params
+}
+
+func expandShippingRateCurrencyOptions(in []interface{}) map[string]*stripe.ShippingRateFixedAmountCurrencyOptionsParams {
+	out := make(map[string]*stripe.ShippingRateFixedAmountCurrencyOptionsParams, len(in))
+	for _, v := range in {
+		option := v.(map[string]interface{})
+		params := &stripe.ShippingRateFixedAmountCurrencyOptionsParams{
+			Amount: stripe.Int64(int64(option["amount"].(int))),
+		}
+		if taxBehavior := option["tax_behavior"].(string); taxBehavior != "" {
+			params.TaxBehavior = stripe.String(taxBehavior)
+		}
+		out[option["currency"].(string)] = params
+	}
+	return out
+}
+
+// flattenShippingRateFixedAmount flattens the shipping rate's amount. Like
+// for prices, the rate's own currency is skipped from the currency options.
+func flattenShippingRateFixedAmount(fixedAmount *stripe.ShippingRateFixedAmount) []interface{} {
+	if fixedAmount == nil {
+		return nil
+	}
+
+	currencyOptions := []interface{}{}
+	for currency, option := range fixedAmount.CurrencyOptions {
+		if currency == string(fixedAmount.Currency) {
+			continue
+		}
+
+		taxBehavior := string(option.TaxBehavior)
+		if taxBehavior == "unspecified" {
+			taxBehavior = ""
+		}
+
+		currencyOptions = append(currencyOptions, map[string]interface{}{
+			"currency":     currency,
+			"amount":       option.Amount,
+			"tax_behavior": taxBehavior,
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"amount":           fixedAmount.Amount,
+			"currency":         string(fixedAmount.Currency),
+			"currency_options": currencyOptions,
+		},
+	}
+}
+
+func expandShippingRateDeliveryEstimate(in []interface{}) *stripe.ShippingRateDeliveryEstimateParams {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	deliveryEstimate := in[0].(map[string]interface{})
+	params := &stripe.ShippingRateDeliveryEstimateParams{}
+	if minimum := deliveryEstimate["minimum"].([]interface{}); len(minimum) > 0 && minimum[0] != nil {
+		bound := minimum[0].(map[string]interface{})
+		params.Minimum = &stripe.ShippingRateDeliveryEstimateMinimumParams{
+			Unit:  stripe.String(bound["unit"].(string)),
+			Value: stripe.Int64(int64(bound["value"].(int))),
+		}
+	}
+	if maximum := deliveryEstimate["maximum"].([]interface{}); len(maximum) > 0 && maximum[0] != nil {
+		bound := maximum[0].(map[string]interface{})
+		params.Maximum = &stripe.ShippingRateDeliveryEstimateMaximumParams{
+			Unit:  stripe.String(bound["unit"].(string)),
+			Value: stripe.Int64(int64(bound["value"].(int))),
+		}
+	}
+	return params
+}
+
+func flattenShippingRateDeliveryEstimate(deliveryEstimate *stripe.ShippingRateDeliveryEstimate) []interface{} {
+	if deliveryEstimate == nil || (deliveryEstimate.Minimum == nil && deliveryEstimate.Maximum == nil) {
+		return nil
+	}
+
+	out := map[string]interface{}{}
+	if deliveryEstimate.Minimum != nil {
+		out["minimum"] = []interface{}{
+			map[string]interface{}{
+				"unit":  string(deliveryEstimate.Minimum.Unit),
+				"value": deliveryEstimate.Minimum.Value,
+			},
+		}
+	}
+	if deliveryEstimate.Maximum != nil {
+		out["maximum"] = []interface{}{
+			map[string]interface{}{
+				"unit":  string(deliveryEstimate.Maximum.Unit),
+				"value": deliveryEstimate.Maximum.Value,
+			},
+		}
+	}
+	return []interface{}{out}
+}
+
+func resourceStripeShippingRateRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.ShippingRateParams{}
+	params.AddExpand("fixed_amount.currency_options") // only returned when expanded
+	setStripeAccount(params, account)
+	shippingRate, err := client.ShippingRates.Get(id, params)
+
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	d.Set("stripe_account", account)
+	d.Set("display_name", shippingRate.DisplayName)
+	d.Set("type", shippingRate.Type)
+	d.Set("fixed_amount", flattenShippingRateFixedAmount(shippingRate.FixedAmount))
+	d.Set("delivery_estimate", flattenShippingRateDeliveryEstimate(shippingRate.DeliveryEstimate))
+	d.Set("tax_behavior", shippingRate.TaxBehavior)
+	if shippingRate.TaxCode != nil {
+		d.Set("tax_code", shippingRate.TaxCode.ID)
+	} else {
+		d.Set("tax_code", "")
+	}
+	d.Set("active", shippingRate.Active)
+	d.Set("metadata", shippingRate.Metadata)
+	d.Set("created", shippingRate.Created)
+	d.Set("livemode", shippingRate.Livemode)
+
+	return nil
+}
+
+func resourceStripeShippingRateUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := stripe.ShippingRateParams{}
+	setStripeAccount(&params, account)
+
+	if d.HasChange("active") {
+		params.Active = stripe.Bool(d.Get("active").(bool))
+	}
+
+	if d.HasChange("metadata") {
+		params.Metadata = expandMetadata(d)
+	}
+
+	// A shipping rate created without a tax behavior gets one set once, see
+	// the CustomizeDiff above.
+	if d.HasChange("tax_behavior") {
+		params.TaxBehavior = stripe.String(d.Get("tax_behavior").(string))
+	}
+
+	if d.HasChange("fixed_amount.0.currency_options") {
+		old, new := d.GetChange("fixed_amount.0.currency_options")
+		params.FixedAmount = &stripe.ShippingRateFixedAmountParams{
+			CurrencyOptions: expandShippingRateCurrencyOptions(new.(*schema.Set).List()),
+		}
+		for _, currency := range removedCurrencies(old.(*schema.Set), new.(*schema.Set)) {
+			params.AddExtra("fixed_amount[currency_options]["+currency+"]", "")
+		}
+	}
+
+	_, err := client.ShippingRates.Update(id, &params)
+	if err != nil {
+		return err
+	}
+
+	return resourceStripeShippingRateRead(d, m)
+}
+
+func resourceStripeShippingRateDelete(d *schema.ResourceData, m interface{}) error {
+	return resourceStripeArchivableDelete(d, m, "shipping rate", func() error {
+		client := m.(*Client)
+		account, id := resourceStripeID(d, m)
+		params := &stripe.ShippingRateParams{
+			Active: stripe.Bool(false),
+		}
+		setStripeAccount(params, account)
+		_, err := client.ShippingRates.Update(id, params)
+		return err
+	})
+}
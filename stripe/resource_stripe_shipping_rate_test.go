@@ -0,0 +1,269 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeShippingRate_basic(t *testing.T) {
+	var before, after stripe.ShippingRate
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeShippingRateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeShippingRateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeShippingRateExists("stripe_shipping_rate.test", &before),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "display_name", "Ground shipping"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "type", "fixed_amount"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "fixed_amount.0.amount", "500"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "fixed_amount.0.currency", "usd"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "fixed_amount.0.currency_options.#", "1"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "delivery_estimate.0.minimum.0.unit", "business_day"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "delivery_estimate.0.minimum.0.value", "3"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "delivery_estimate.0.maximum.0.value", "5"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "tax_behavior", "unspecified"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "tax_code", "txcd_92010001"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "metadata.carrier", "ups"),
+				),
+			},
+			{
+				Config: testAccStripeShippingRateConfigUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeShippingRateExists("stripe_shipping_rate.test", &after),
+					testAccCheckStripeShippingRateNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "fixed_amount.0.currency_options.#", "1"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "tax_behavior", "exclusive"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_shipping_rate.test", "metadata.carrier", "dhl"),
+					testAccCheckStripeShippingRateCurrencyOptions(&after, "gbp"),
+				),
+			},
+			{
+				ResourceName:            "stripe_shipping_rate.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"on_destroy"},
+			},
+		},
+	})
+}
+
+// The provider's on_destroy takes precedence over archiving shipping rates.
+func TestAccStripeShippingRate_providerOnDestroy(t *testing.T) {
+	var shippingRate stripe.ShippingRate
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeShippingRateForgotten,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "stripe" {
+  on_destroy = "forget"
+}
+` + testAccStripeShippingRateConfig,
+				Check: testAccCheckStripeShippingRateExists("stripe_shipping_rate.test", &shippingRate),
+			},
+		},
+	})
+}
+
+// Even the provider's on_destroy = "error" overrides archiving shipping rates.
+func TestAccStripeShippingRate_providerOnDestroyError(t *testing.T) {
+	config := `
+provider "stripe" {
+  on_destroy = "error"
+}
+` + testAccStripeShippingRateConfig
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeShippingRateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Stripe doesn't allow deleting shipping rates`),
+			},
+			{
+				Config: testAccStripeShippingRateConfig,
+			},
+		},
+	})
+}
+
+func testAccCheckStripeShippingRateExists(n string, shippingRate *stripe.ShippingRate) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.ShippingRates.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*shippingRate = *found
+		return nil
+	}
+}
+
+func testAccCheckStripeShippingRateNotRecreated(before, after *stripe.ShippingRate) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID != after.ID {
+			return fmt.Errorf("expected shipping rate %s to be updated, it was replaced by %s", before.ID, after.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckStripeShippingRateCurrencyOptions checks the shipping rate's
+// additional currencies.
+func testAccCheckStripeShippingRateCurrencyOptions(shippingRate *stripe.ShippingRate, currencies ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		expected := map[string]bool{string(shippingRate.FixedAmount.Currency): true}
+		for _, currency := range currencies {
+			expected[currency] = true
+		}
+
+		for currency := range shippingRate.FixedAmount.CurrencyOptions {
+			if !expected[currency] {
+				return fmt.Errorf("expected shipping rate %s not to have currency %s", shippingRate.ID, currency)
+			}
+			delete(expected, currency)
+		}
+
+		if len(expected) > 0 {
+			return fmt.Errorf("expected shipping rate %s to have currencies %v", shippingRate.ID, getMapKeys(expected))
+		}
+		return nil
+	}
+}
+
+// Shipping rates can't be deleted, destroying them archives them.
+func testAccCheckStripeShippingRateDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_shipping_rate" {
+			continue
+		}
+
+		shippingRate, err := client.ShippingRates.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if shippingRate.Active {
+			return fmt.Errorf("shipping rate %s should have been archived", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckStripeShippingRateForgotten(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_shipping_rate" {
+			continue
+		}
+
+		shippingRate, err := client.ShippingRates.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if !shippingRate.Active {
+			return fmt.Errorf("shipping rate %s should have been left active", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccStripeShippingRateConfig = `
+resource "stripe_shipping_rate" "test" {
+  display_name = "Ground shipping"
+  tax_code     = "txcd_92010001"
+
+  fixed_amount {
+    amount   = 500
+    currency = "usd"
+
+    currency_options {
+      currency = "eur"
+      amount   = 450
+    }
+  }
+
+  delivery_estimate {
+    minimum {
+      unit  = "business_day"
+      value = 3
+    }
+
+    maximum {
+      unit  = "business_day"
+      value = 5
+    }
+  }
+
+  metadata = {
+    carrier = "ups"
+  }
+}
+`
+
+const testAccStripeShippingRateConfigUpdated = `
+resource "stripe_shipping_rate" "test" {
+  display_name = "Ground shipping"
+  tax_code     = "txcd_92010001"
+  tax_behavior = "exclusive"
+
+  fixed_amount {
+    amount   = 500
+    currency = "usd"
+
+    currency_options {
+      currency     = "gbp"
+      amount       = 400
+      tax_behavior = "exclusive"
+    }
+  }
+
+  delivery_estimate {
+    minimum {
+      unit  = "business_day"
+      value = 3
+    }
+
+    maximum {
+      unit  = "business_day"
+      value = 5
+    }
+  }
+
+  metadata = {
+    carrier = "dhl"
+  }
+}
+`
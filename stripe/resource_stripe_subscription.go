@@ -0,0 +1,509 @@
+package stripe
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStripeSubscriptionCreate,
+		Read:   resourceStripeSubscriptionRead,
+		Update: resourceStripeSubscriptionUpdate,
+		Delete: resourceStripeSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"item": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Set:      resourceStripeSubscriptionItemHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"price": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"quantity": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1, // metered prices have no quantity, set it to 0
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"tax_rates": &schema.Schema{
+							Type: schema.TypeList,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional: true,
+						},
+						// Computed
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"proration_behavior": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "create_prorations",
+				ValidateFunc: validation.StringInSlice([]string{"create_prorations", "none", "always_invoice"}, false),
+			},
+			"default_tax_rates": &schema.Schema{
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"collection_method": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "charge_automatically",
+				ValidateFunc: validation.StringInSlice([]string{"charge_automatically", "send_invoice"}, false),
+			},
+			"days_until_due": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"billing_cycle_anchor": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true, // defaults to the subscription's start
+				ForceNew:         true,
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+			},
+			"cancel_at_period_end": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"trial_end": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true, // set from the prices' trial periods
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+			},
+			"coupon": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"promotion_code"},
+			},
+			"promotion_code": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"coupon"},
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"invoice_now": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"prorate": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			// Computed
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_period_start": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"current_period_end": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
+		},
+	}
+}
+
+// Items are identified by their price, quantity and tax rates so that the
+// computed item ID doesn't take part in the diff.
+func resourceStripeSubscriptionItemHash(v interface{}) int {
+	item := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s-%d-%v", item["price"], item["quantity"], item["tax_rates"]))
+}
+
+func resourceStripeSubscriptionCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.SubscriptionParams{
+		Customer:         stripe.String(d.Get("customer").(string)),
+		CollectionMethod: stripe.String(d.Get("collection_method").(string)),
+	}
+
+	for _, v := range d.Get("item").(*schema.Set).List() {
+		item := v.(map[string]interface{})
+		itemParams := &stripe.SubscriptionItemsParams{
+			Price:    stripe.String(item["price"].(string)),
+			TaxRates: expandStringSlice(item["tax_rates"].([]interface{})),
+		}
+		if quantity := item["quantity"].(int); quantity > 0 {
+			itemParams.Quantity = stripe.Int64(int64(quantity))
+		}
+		params.Items = append(params.Items, itemParams)
+	}
+
+	if _, ok := d.GetOk("default_tax_rates"); ok {
+		params.DefaultTaxRates = expandStringList(d, "default_tax_rates")
+	}
+
+	if daysUntilDue, ok := d.GetOk("days_until_due"); ok {
+		params.DaysUntilDue = stripe.Int64(int64(daysUntilDue.(int)))
+	}
+
+	if billingCycleAnchor, ok := d.GetOk("billing_cycle_anchor"); ok {
+		timestamp, err := expandTimestamp(billingCycleAnchor.(string))
+		if err != nil {
+			return fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", billingCycleAnchor)
+		}
+		params.BillingCycleAnchor = stripe.Int64(timestamp)
+	}
+
+	if cancelAtPeriodEnd, ok := d.GetOk("cancel_at_period_end"); ok {
+		params.CancelAtPeriodEnd = stripe.Bool(cancelAtPeriodEnd.(bool))
+	}
+
+	if trialEnd, ok := d.GetOk("trial_end"); ok {
+		timestamp, err := expandTimestamp(trialEnd.(string))
+		if err != nil {
+			return fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", trialEnd)
+		}
+		params.TrialEnd = stripe.Int64(timestamp)
+	}
+
+	if coupon, ok := d.GetOk("coupon"); ok {
+		params.Coupon = stripe.String(coupon.(string))
+	}
+
+	if promotionCode, ok := d.GetOk("promotion_code"); ok {
+		params.PromotionCode = stripe.String(promotionCode.(string))
+	}
+
+	params.Metadata = expandMetadata(d)
+
+	setStripeAccount(params, account)
+	var subscription *stripe.Subscription
+	err := createIdempotently(client, "subscriptions", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		subscription, err = client.Subscriptions.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return subscription.ID, subscription.LastResponse, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Create subscription: %s", subscription.ID)
+	d.SetId(stripeResourceID(account, subscription.ID))
+
+	return resourceStripeSubscriptionRead(d, m)
+}
+
+func resourceStripeSubscriptionRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.SubscriptionParams{}
+	setStripeAccount(params, account)
+	subscription, err := client.Subscriptions.Get(id, params)
+
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	if subscription.Status == stripe.SubscriptionStatusCanceled {
+		log.Printf("[WARN] Subscription %s was canceled, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	items, err := resourceStripeSubscriptionListItems(client, account, id)
+	if err != nil {
+		return err
+	}
+
+	d.Set("stripe_account", account)
+	if subscription.Customer != nil {
+		d.Set("customer", subscription.Customer.ID)
+	}
+	d.Set("item", flattenSubscriptionItems(items))
+	d.Set("default_tax_rates", flattenTaxRateIDs(subscription.DefaultTaxRates))
+	d.Set("collection_method", subscription.CollectionMethod)
+	d.Set("days_until_due", subscription.DaysUntilDue)
+	d.Set("billing_cycle_anchor", flattenTimestamp(subscription.BillingCycleAnchor))
+	d.Set("cancel_at_period_end", subscription.CancelAtPeriodEnd)
+	d.Set("trial_end", flattenTimestamp(subscription.TrialEnd))
+	d.Set("coupon", "")
+	d.Set("promotion_code", "")
+	if discount := subscription.Discount; discount != nil {
+		// Promotion codes apply their coupon, only report one of them.
+		if discount.PromotionCode != nil {
+			d.Set("promotion_code", discount.PromotionCode.ID)
+		} else if discount.Coupon != nil {
+			d.Set("coupon", discount.Coupon.ID)
+		}
+	}
+	d.Set("metadata", subscription.Metadata)
+	d.Set("status", subscription.Status)
+	d.Set("current_period_start", subscription.CurrentPeriodStart)
+	d.Set("current_period_end", subscription.CurrentPeriodEnd)
+	d.Set("created", subscription.Created)
+	d.Set("livemode", subscription.Livemode)
+
+	return nil
+}
+
+func resourceStripeSubscriptionListItems(client *Client, account, subscriptionID string) ([]*stripe.SubscriptionItem, error) {
+	params := &stripe.SubscriptionItemListParams{
+		Subscription: stripe.String(subscriptionID),
+	}
+	setStripeAccount(params, account)
+
+	var items []*stripe.SubscriptionItem
+	i := client.SubscriptionItems.List(params)
+	for i.Next() {
+		items = append(items, i.SubscriptionItem())
+	}
+
+	return items, i.Err()
+}
+
+func flattenSubscriptionItems(items []*stripe.SubscriptionItem) *schema.Set {
+	out := &schema.Set{F: resourceStripeSubscriptionItemHash}
+	for _, item := range items {
+		var price string
+		if item.Price != nil {
+			price = item.Price.ID
+		}
+
+		out.Add(map[string]interface{}{
+			"price":     price,
+			"quantity":  int(item.Quantity),
+			"tax_rates": flattenTaxRateIDs(item.TaxRates),
+			"id":        item.ID,
+		})
+	}
+	return out
+}
+
+func flattenTaxRateIDs(taxRates []*stripe.TaxRate) []interface{} {
+	out := make([]interface{}, len(taxRates))
+	for i, taxRate := range taxRates {
+		out[i] = taxRate.ID
+	}
+	return out
+}
+
+func resourceStripeSubscriptionUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	prorationBehavior := d.Get("proration_behavior").(string)
+	params := stripe.SubscriptionParams{
+		ProrationBehavior: stripe.String(prorationBehavior),
+	}
+	setStripeAccount(&params, account)
+
+	if d.HasChange("default_tax_rates") {
+		if params.DefaultTaxRates = expandStringList(d, "default_tax_rates"); len(params.DefaultTaxRates) == 0 {
+			params.AddExtra("default_tax_rates", "")
+		}
+	}
+
+	if d.HasChange("collection_method") {
+		params.CollectionMethod = stripe.String(d.Get("collection_method").(string))
+	}
+
+	if d.HasChange("days_until_due") {
+		if daysUntilDue, ok := d.GetOk("days_until_due"); ok {
+			params.DaysUntilDue = stripe.Int64(int64(daysUntilDue.(int)))
+		} else {
+			params.AddExtra("days_until_due", "")
+		}
+	}
+
+	if d.HasChange("cancel_at_period_end") {
+		params.CancelAtPeriodEnd = stripe.Bool(d.Get("cancel_at_period_end").(bool))
+	}
+
+	if d.HasChange("trial_end") {
+		timestamp, err := expandTimestamp(d.Get("trial_end").(string))
+		if err != nil {
+			return fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", d.Get("trial_end"))
+		}
+		params.TrialEnd = stripe.Int64(timestamp)
+	}
+
+	// An empty coupon removes the subscription's discount.
+	if d.HasChange("coupon") || d.HasChange("promotion_code") {
+		if promotionCode := d.Get("promotion_code").(string); promotionCode != "" {
+			params.PromotionCode = stripe.String(promotionCode)
+		} else {
+			params.Coupon = stripe.String(d.Get("coupon").(string))
+		}
+	}
+
+	if d.HasChange("metadata") {
+		params.Metadata = expandMetadata(d)
+	}
+
+	_, err := client.Subscriptions.Update(id, &params)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("item") {
+		old, new := d.GetChange("item")
+		err = resourceStripeSubscriptionUpdateItems(client, account, id, prorationBehavior, old.(*schema.Set), new.(*schema.Set))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceStripeSubscriptionRead(d, m)
+}
+
+// resourceStripeSubscriptionUpdateItems applies item changes through the
+// SubscriptionItems API. Existing items are tracked by the ID stored in the
+// state: unchanged items are kept, items whose price is still used are
+// updated, and new ones are added before the removed ones are deleted, so
+// that the subscription always keeps at least one item.
+func resourceStripeSubscriptionUpdateItems(client *Client, account, subscriptionID, prorationBehavior string, old, new *schema.Set) error {
+	oldItems := map[string]map[string]interface{}{}
+	for _, v := range old.List() {
+		item := v.(map[string]interface{})
+		oldItems[item["id"].(string)] = item
+	}
+
+	// claimOldItem returns the ID of an existing item matching, each item
+	// being claimed at most once.
+	claimOldItem := func(match func(map[string]interface{}) bool) string {
+		for _, v := range old.List() {
+			item := v.(map[string]interface{})
+			id := item["id"].(string)
+			if _, ok := oldItems[id]; ok && match(item) {
+				delete(oldItems, id)
+				return id
+			}
+		}
+		return ""
+	}
+
+	var changed []map[string]interface{}
+	for _, v := range new.List() {
+		item := v.(map[string]interface{})
+		hash := resourceStripeSubscriptionItemHash(item)
+		unchanged := claimOldItem(func(oldItem map[string]interface{}) bool {
+			return resourceStripeSubscriptionItemHash(oldItem) == hash
+		})
+		if unchanged == "" {
+			changed = append(changed, item)
+		}
+	}
+
+	for _, item := range changed {
+		params := &stripe.SubscriptionItemParams{
+			ProrationBehavior: stripe.String(prorationBehavior),
+			TaxRates:          expandStringSlice(item["tax_rates"].([]interface{})),
+		}
+		if quantity := item["quantity"].(int); quantity > 0 {
+			params.Quantity = stripe.Int64(int64(quantity))
+		}
+		setStripeAccount(params, account)
+
+		id := claimOldItem(func(oldItem map[string]interface{}) bool {
+			return oldItem["price"] == item["price"]
+		})
+		if id != "" {
+			if len(params.TaxRates) == 0 {
+				params.AddExtra("tax_rates", "")
+			}
+			if _, err := client.SubscriptionItems.Update(id, params); err != nil {
+				return err
+			}
+			log.Printf("[INFO] Updated item %s of subscription %s", id, subscriptionID)
+			continue
+		}
+
+		params.Subscription = stripe.String(subscriptionID)
+		params.Price = stripe.String(item["price"].(string))
+		created, err := client.SubscriptionItems.New(params)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Added item %s to subscription %s", created.ID, subscriptionID)
+	}
+
+	for id := range oldItems {
+		params := &stripe.SubscriptionItemParams{
+			ProrationBehavior: stripe.String(prorationBehavior),
+		}
+		setStripeAccount(params, account)
+		if _, err := client.SubscriptionItems.Del(id, params); err != nil {
+			return err
+		}
+		log.Printf("[INFO] Deleted item %s of subscription %s", id, subscriptionID)
+	}
+
+	return nil
+}
+
+func resourceStripeSubscriptionDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.SubscriptionCancelParams{
+		InvoiceNow: stripe.Bool(d.Get("invoice_now").(bool)),
+		Prorate:    stripe.Bool(d.Get("prorate").(bool)),
+	}
+	setStripeAccount(params, account)
+	_, err := client.Subscriptions.Cancel(id, params)
+
+	if err == nil {
+		log.Printf("[INFO] Canceled subscription: %s", d.Id())
+		d.SetId("")
+	}
+
+	return err
+}
@@ -0,0 +1,497 @@
+package stripe
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func resourceStripeSubscriptionSchedule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStripeSubscriptionScheduleCreate,
+		Read:   resourceStripeSubscriptionScheduleRead,
+		Update: resourceStripeSubscriptionScheduleUpdate,
+		Delete: resourceStripeSubscriptionScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceStripeSubscriptionScheduleValidate,
+
+		Schema: map[string]*schema.Schema{
+			"customer": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				// Not computed so that leaving it out can be told apart
+				// from a customer that isn't known yet, schedules created
+				// from a subscription read the subscription's customer.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return new == "" && d.Get("from_subscription").(string) != ""
+				},
+				ConflictsWith: []string{"from_subscription"},
+			},
+			"from_subscription": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"customer"},
+			},
+			"phase": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"item": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"price": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"quantity": &schema.Schema{
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1, // metered prices have no quantity, set it to 0
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"tax_rates": &schema.Schema{
+										Type: schema.TypeList,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+										Optional: true,
+									},
+								},
+							},
+						},
+						"start_date": &schema.Schema{
+							Type:             schema.TypeString,
+							Optional:         true,
+							Computed:         true, // now, or the end of the previous phase
+							ValidateFunc:     validation.ValidateRFC3339TimeString,
+							DiffSuppressFunc: suppressEquivalentTimestamps,
+						},
+						"end_date": &schema.Schema{
+							Type:             schema.TypeString,
+							Optional:         true,
+							Computed:         true, // derived from iterations
+							ValidateFunc:     validation.ValidateRFC3339TimeString,
+							DiffSuppressFunc: suppressEquivalentTimestamps,
+						},
+						"iterations": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"coupon": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"default_tax_rates": &schema.Schema{
+							Type: schema.TypeList,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional: true,
+						},
+						"proration_behavior": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "create_prorations",
+							ValidateFunc: validation.StringInSlice([]string{"create_prorations", "none", "always_invoice"}, false),
+						},
+						"trial": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"end_behavior": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "release",
+				ValidateFunc: validation.StringInSlice([]string{"release", "cancel", "none"}, false),
+			},
+			"destroy_behavior": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "release",
+				ValidateFunc: validation.StringInSlice([]string{"release", "cancel"}, false),
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subscription": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"livemode": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
+		},
+	}
+}
+
+// resourceStripeSubscriptionScheduleValidate rejects what Stripe doesn't
+// allow: schedules need exactly one of a customer or a subscription, phases
+// that ended can't be changed anymore, and neither can the start of the phase
+// in progress.
+func resourceStripeSubscriptionScheduleValidate(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		if d.NewValueKnown("customer") && d.Get("customer").(string) == "" &&
+			d.NewValueKnown("from_subscription") && d.Get("from_subscription").(string) == "" {
+			return fmt.Errorf("one of customer or from_subscription must be set")
+		}
+		return nil
+	}
+
+	if !d.HasChange("phase") {
+		return nil
+	}
+
+	old, new := d.GetChange("phase")
+	oldPhases, newPhases := old.([]interface{}), new.([]interface{})
+	now := time.Now().Unix()
+
+	for i, v := range oldPhases {
+		oldPhase := v.(map[string]interface{})
+		startDate, err := expandTimestamp(oldPhase["start_date"].(string))
+		if err != nil || startDate > now {
+			continue
+		}
+
+		if i >= len(newPhases) {
+			return fmt.Errorf("phase %d started on %s and can't be removed", i, oldPhase["start_date"])
+		}
+
+		newPhase := newPhases[i].(map[string]interface{})
+		if !suppressEquivalentTimestamps("", oldPhase["start_date"].(string), newPhase["start_date"].(string), nil) {
+			return fmt.Errorf("phase.%d.start_date can't be changed, the phase started on %s", i, oldPhase["start_date"])
+		}
+
+		endDate, err := expandTimestamp(oldPhase["end_date"].(string))
+		if err == nil && endDate <= now && !subscriptionSchedulePhasesEqual(oldPhase, newPhase) {
+			return fmt.Errorf("phase %d ended on %s and can't be changed", i, oldPhase["end_date"])
+		}
+	}
+
+	return nil
+}
+
+func subscriptionSchedulePhasesEqual(a, b map[string]interface{}) bool {
+	normalized := make([]map[string]interface{}, 2)
+	for i, phase := range []map[string]interface{}{a, b} {
+		normalized[i] = map[string]interface{}{}
+		for k, v := range phase {
+			if k == "start_date" || k == "end_date" {
+				v, _ = expandTimestamp(v.(string))
+			}
+			normalized[i][k] = v
+		}
+	}
+	return reflect.DeepEqual(normalized[0], normalized[1])
+}
+
+func resourceStripeSubscriptionScheduleCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
+	params := &stripe.SubscriptionScheduleParams{}
+
+	// Schedules created from a subscription start with the subscription's
+	// current phase, the configured phases are set right after.
+	fromSubscription, fromSubscriptionOk := d.GetOk("from_subscription")
+	if fromSubscriptionOk {
+		params.FromSubscription = stripe.String(fromSubscription.(string))
+	} else {
+		params.Customer = stripe.String(d.Get("customer").(string))
+		params.EndBehavior = stripe.String(d.Get("end_behavior").(string))
+		params.Metadata = expandMetadata(d)
+
+		phases, err := expandSubscriptionSchedulePhases(d.Get("phase").([]interface{}))
+		if err != nil {
+			return err
+		}
+		// The schedule starts with its first phase.
+		if phases[0].StartDate != nil {
+			params.StartDate = phases[0].StartDate
+			phases[0].StartDate = nil
+		} else {
+			params.StartDateNow = stripe.Bool(true)
+		}
+		params.Phases = phases
+	}
+
+	setStripeAccount(params, account)
+	var schedule *stripe.SubscriptionSchedule
+	err := createIdempotently(client, "subscription_schedules", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		schedule, err = client.SubscriptionSchedules.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return schedule.ID, schedule.LastResponse, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Create subscription schedule: %s", schedule.ID)
+	d.SetId(stripeResourceID(account, schedule.ID))
+
+	if fromSubscriptionOk {
+		params := &stripe.SubscriptionScheduleParams{
+			EndBehavior: stripe.String(d.Get("end_behavior").(string)),
+		}
+		params.Metadata = expandMetadata(d)
+
+		params.Phases, err = expandSubscriptionSchedulePhases(d.Get("phase").([]interface{}))
+		if err != nil {
+			return err
+		}
+		if params.Phases[0].StartDate == nil && len(schedule.Phases) > 0 {
+			params.Phases[0].StartDate = stripe.Int64(schedule.Phases[0].StartDate)
+		}
+
+		setStripeAccount(params, account)
+		if _, err := client.SubscriptionSchedules.Update(schedule.ID, params); err != nil {
+			return err
+		}
+	}
+
+	return resourceStripeSubscriptionScheduleRead(d, m)
+}
+
+func expandSubscriptionSchedulePhases(in []interface{}) ([]*stripe.SubscriptionSchedulePhaseParams, error) {
+	out := make([]*stripe.SubscriptionSchedulePhaseParams, len(in))
+	for i, v := range in {
+		phase := v.(map[string]interface{})
+		params := &stripe.SubscriptionSchedulePhaseParams{
+			DefaultTaxRates:   expandStringSlice(phase["default_tax_rates"].([]interface{})),
+			ProrationBehavior: stripe.String(phase["proration_behavior"].(string)),
+		}
+
+		for _, it := range phase["item"].([]interface{}) {
+			item := it.(map[string]interface{})
+			itemParams := &stripe.SubscriptionSchedulePhaseItemParams{
+				Price:    stripe.String(item["price"].(string)),
+				TaxRates: expandStringSlice(item["tax_rates"].([]interface{})),
+			}
+			if quantity := item["quantity"].(int); quantity > 0 {
+				itemParams.Quantity = stripe.Int64(int64(quantity))
+			}
+			params.Items = append(params.Items, itemParams)
+		}
+
+		// Only the first phase has a start date, the others start when
+		// the previous one ends.
+		if startDate := phase["start_date"].(string); i == 0 && startDate != "" {
+			timestamp, err := expandTimestamp(startDate)
+			if err != nil {
+				return nil, fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", startDate)
+			}
+			params.StartDate = stripe.Int64(timestamp)
+		}
+
+		// Iterations take precedence over the end date they were converted
+		// into by Stripe.
+		if iterations := phase["iterations"].(int); iterations > 0 {
+			params.Iterations = stripe.Int64(int64(iterations))
+		} else if endDate := phase["end_date"].(string); endDate != "" {
+			timestamp, err := expandTimestamp(endDate)
+			if err != nil {
+				return nil, fmt.Errorf("can't convert time \"%s\" to time.  Please check if it's RFC3339-compliant", endDate)
+			}
+			params.EndDate = stripe.Int64(timestamp)
+		}
+
+		if coupon := phase["coupon"].(string); coupon != "" {
+			params.Coupon = stripe.String(coupon)
+		}
+
+		if phase["trial"].(bool) {
+			params.Trial = stripe.Bool(true)
+		}
+
+		out[i] = params
+	}
+	return out, nil
+}
+
+func resourceStripeSubscriptionScheduleRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.SubscriptionScheduleParams{}
+	setStripeAccount(params, account)
+	schedule, err := client.SubscriptionSchedules.Get(id, params)
+
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	switch schedule.Status {
+	case stripe.SubscriptionScheduleStatusCanceled, stripe.SubscriptionScheduleStatusReleased:
+		log.Printf("[WARN] Subscription schedule %s was %s, removing it from the state", d.Id(), schedule.Status)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("stripe_account", account)
+	if schedule.Customer != nil {
+		d.Set("customer", schedule.Customer.ID)
+	}
+	d.Set("phase", flattenSubscriptionSchedulePhases(schedule.Phases, d.Get("phase").([]interface{})))
+	d.Set("end_behavior", schedule.EndBehavior)
+	d.Set("metadata", schedule.Metadata)
+	d.Set("status", schedule.Status)
+	if schedule.Subscription != nil {
+		d.Set("subscription", schedule.Subscription.ID)
+	} else {
+		d.Set("subscription", "")
+	}
+	d.Set("created", schedule.Created)
+	d.Set("livemode", schedule.Livemode)
+
+	return nil
+}
+
+// flattenSubscriptionSchedulePhases flattens the schedule's phases. Stripe
+// converts iterations into end dates, so they are kept from the state.
+func flattenSubscriptionSchedulePhases(phases []*stripe.SubscriptionSchedulePhase, current []interface{}) []interface{} {
+	out := make([]interface{}, len(phases))
+	for i, phase := range phases {
+		items := make([]interface{}, len(phase.Items))
+		for j, item := range phase.Items {
+			var price string
+			if item.Price != nil {
+				price = item.Price.ID
+			}
+			items[j] = map[string]interface{}{
+				"price":     price,
+				"quantity":  item.Quantity,
+				"tax_rates": flattenTaxRateIDs(item.TaxRates),
+			}
+		}
+
+		var coupon string
+		if phase.Coupon != nil {
+			coupon = phase.Coupon.ID
+		}
+
+		var iterations int
+		if i < len(current) && current[i] != nil {
+			iterations = current[i].(map[string]interface{})["iterations"].(int)
+		}
+
+		out[i] = map[string]interface{}{
+			"item":               items,
+			"start_date":         flattenTimestamp(phase.StartDate),
+			"end_date":           flattenTimestamp(phase.EndDate),
+			"iterations":         iterations,
+			"coupon":             coupon,
+			"default_tax_rates":  flattenTaxRateIDs(phase.DefaultTaxRates),
+			"proration_behavior": string(phase.ProrationBehavior),
+			"trial":              phase.TrialEnd != 0 && phase.TrialEnd >= phase.EndDate,
+		}
+	}
+	return out
+}
+
+func resourceStripeSubscriptionScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := stripe.SubscriptionScheduleParams{}
+	setStripeAccount(&params, account)
+
+	if d.HasChange("phase") {
+		// Phases that ended can't be sent again, Stripe keeps them as is.
+		phases := d.Get("phase").([]interface{})
+		now := time.Now().Unix()
+		for len(phases) > 1 {
+			endDate, err := expandTimestamp(phases[0].(map[string]interface{})["end_date"].(string))
+			if err != nil || endDate > now {
+				break
+			}
+			phases = phases[1:]
+		}
+
+		phaseParams, err := expandSubscriptionSchedulePhases(phases)
+		if err != nil {
+			return err
+		}
+		params.Phases = phaseParams
+	}
+
+	if d.HasChange("end_behavior") {
+		params.EndBehavior = stripe.String(d.Get("end_behavior").(string))
+	}
+
+	if d.HasChange("metadata") {
+		params.Metadata = expandMetadata(d)
+	}
+
+	_, err := client.SubscriptionSchedules.Update(id, &params)
+	if err != nil {
+		return err
+	}
+
+	return resourceStripeSubscriptionScheduleRead(d, m)
+}
+
+// Subscription schedules can't be deleted: destroying one either releases it,
+// leaving its subscription running on its own, or cancels both.
+func resourceStripeSubscriptionScheduleDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+
+	var err error
+	switch d.Get("destroy_behavior").(string) {
+	case "cancel":
+		params := &stripe.SubscriptionScheduleCancelParams{}
+		setStripeAccount(params, account)
+		_, err = client.SubscriptionSchedules.Cancel(id, params)
+	default:
+		params := &stripe.SubscriptionScheduleReleaseParams{}
+		setStripeAccount(params, account)
+		_, err = client.SubscriptionSchedules.Release(id, params)
+	}
+
+	if err == nil {
+		log.Printf("[INFO] Destroyed subscription schedule %s: %s", d.Id(), d.Get("destroy_behavior"))
+		d.SetId("")
+	}
+
+	return err
+}
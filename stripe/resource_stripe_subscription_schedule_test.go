@@ -0,0 +1,320 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeSubscriptionSchedule_basic(t *testing.T) {
+	var schedule stripe.SubscriptionSchedule
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeSubscriptionScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeSubscriptionScheduleConfig(5, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeSubscriptionScheduleExists("stripe_subscription_schedule.test", &schedule),
+					resource.TestCheckResourceAttrPair("stripe_subscription_schedule.test", "customer", "stripe_customer.test", "id"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "status", "active"),
+					resource.TestCheckResourceAttrSet("stripe_subscription_schedule.test", "subscription"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.#", "3"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.0.start_date", "2020-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.0.end_date", "2021-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.0.trial", "true"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.1.start_date", "2021-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.1.item.0.quantity", "10"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.1.coupon", "LOYALTY"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.1.default_tax_rates.#", "1"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.2.start_date", "2040-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.2.end_date", "2040-03-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.2.iterations", "2"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "end_behavior", "cancel"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "metadata.contract", "C-1234"),
+				),
+			},
+			{
+				// Phases that didn't end yet can be changed, the ones that
+				// did are kept.
+				Config: testAccStripeSubscriptionScheduleConfig(5, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeSubscriptionScheduleExists("stripe_subscription_schedule.test", &schedule),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.#", "3"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.0.start_date", "2020-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.0.end_date", "2021-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.0.item.0.quantity", "5"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "phase.1.item.0.quantity", "20"),
+				),
+			},
+			{
+				Config:      testAccStripeSubscriptionScheduleConfig(15, 20),
+				ExpectError: regexp.MustCompile(`phase 0 ended on 2021-01-01T00:00:00Z and can't be changed`),
+			},
+			{
+				ResourceName:            "stripe_subscription_schedule.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"phase.2.iterations", "destroy_behavior"},
+			},
+		},
+	})
+}
+
+func TestAccStripeSubscriptionSchedule_cancel(t *testing.T) {
+	var schedule stripe.SubscriptionSchedule
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeSubscriptionScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeSubscriptionScheduleConfigCancel,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeSubscriptionScheduleExists("stripe_subscription_schedule.test", &schedule),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "status", "not_started"),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "subscription", ""),
+					resource.TestCheckResourceAttr("stripe_subscription_schedule.test", "destroy_behavior", "cancel"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripeSubscriptionSchedule_fromSubscription(t *testing.T) {
+	var schedule stripe.SubscriptionSchedule
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeSubscriptionScheduleConfigFromSubscription,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeSubscriptionScheduleExists("stripe_subscription_schedule.test", &schedule),
+					resource.TestCheckResourceAttrPair("stripe_subscription_schedule.test", "customer", "stripe_customer.test", "id"),
+					resource.TestCheckResourceAttrPair("stripe_subscription_schedule.test", "subscription", "stripe_subscription.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripeSubscriptionSchedule_noCustomer(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccStripeSubscriptionScheduleConfigNoCustomer,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`one of customer or from_subscription must be set`),
+			},
+		},
+	})
+}
+
+func testAccCheckStripeSubscriptionScheduleExists(n string, schedule *stripe.SubscriptionSchedule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.SubscriptionSchedules.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*schedule = *found
+		return nil
+	}
+}
+
+// Subscription schedules aren't deleted, destroying them releases or cancels
+// them depending on destroy_behavior.
+func testAccCheckStripeSubscriptionScheduleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_subscription_schedule" {
+			continue
+		}
+
+		schedule, err := client.SubscriptionSchedules.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		expected := stripe.SubscriptionScheduleStatusReleased
+		if rs.Primary.Attributes["destroy_behavior"] == "cancel" {
+			expected = stripe.SubscriptionScheduleStatusCanceled
+		}
+		if schedule.Status != expected {
+			return fmt.Errorf("expected subscription schedule %s to be %s, it is %s", rs.Primary.ID, expected, schedule.Status)
+		}
+
+		if expected == stripe.SubscriptionScheduleStatusReleased && schedule.ReleasedSubscription != nil {
+			subscription, err := client.Subscriptions.Get(schedule.ReleasedSubscription.ID, nil)
+			if err != nil {
+				return err
+			}
+			if subscription.Status == stripe.SubscriptionStatusCanceled {
+				return fmt.Errorf("expected subscription %s to keep running after its schedule was released", subscription.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+const testAccStripeSubscriptionScheduleConfigPrices = `
+resource "stripe_customer" "test" {
+  email = "procurement@acme.example"
+  name  = "ACME Corp"
+}
+
+resource "stripe_product" "test" {
+  name = "Platform"
+  type = "service"
+}
+
+resource "stripe_price" "seats" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 2000
+
+  recurring {
+    interval = "month"
+  }
+}
+
+resource "stripe_price" "enterprise" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 150000
+
+  recurring {
+    interval = "month"
+  }
+}
+`
+
+func testAccStripeSubscriptionScheduleConfig(pilotSeats, seats int) string {
+	return testAccStripeSubscriptionScheduleConfigPrices + fmt.Sprintf(`
+resource "stripe_tax_rate" "vat" {
+  display_name = "VAT"
+  jurisdiction = "DE"
+  percentage   = 19
+  inclusive    = false
+  active       = true
+}
+
+resource "stripe_coupon" "loyalty" {
+  code        = "LOYALTY"
+  duration    = "forever"
+  percent_off = 10
+}
+
+resource "stripe_subscription_schedule" "test" {
+  customer     = stripe_customer.test.id
+  end_behavior = "cancel"
+
+  phase {
+    start_date = "2020-01-01T00:00:00Z"
+    end_date   = "2021-01-01T00:00:00Z"
+    trial      = true
+
+    item {
+      price    = stripe_price.seats.id
+      quantity = %d
+    }
+  }
+
+  phase {
+    end_date          = "2040-01-01T00:00:00Z"
+    coupon            = stripe_coupon.loyalty.id
+    default_tax_rates = [stripe_tax_rate.vat.id]
+
+    item {
+      price    = stripe_price.seats.id
+      quantity = %d
+    }
+  }
+
+  phase {
+    iterations         = 2
+    proration_behavior = "none"
+
+    item {
+      price = stripe_price.enterprise.id
+    }
+  }
+
+  metadata = {
+    contract = "C-1234"
+  }
+}
+`, pilotSeats, seats)
+}
+
+var testAccStripeSubscriptionScheduleConfigCancel = testAccStripeSubscriptionScheduleConfigPrices + `
+resource "stripe_subscription_schedule" "test" {
+  customer         = stripe_customer.test.id
+  destroy_behavior = "cancel"
+
+  phase {
+    start_date = "2040-01-01T00:00:00Z"
+
+    item {
+      price = stripe_price.enterprise.id
+    }
+  }
+}
+`
+
+var testAccStripeSubscriptionScheduleConfigNoCustomer = testAccStripeSubscriptionScheduleConfigPrices + `
+resource "stripe_subscription_schedule" "test" {
+  phase {
+    item {
+      price = stripe_price.enterprise.id
+    }
+  }
+}
+`
+
+var testAccStripeSubscriptionScheduleConfigFromSubscription = testAccStripeSubscriptionScheduleConfigPrices + `
+resource "stripe_subscription" "test" {
+  customer = stripe_customer.test.id
+
+  item {
+    price = stripe_price.seats.id
+  }
+}
+
+resource "stripe_subscription_schedule" "test" {
+  from_subscription = stripe_subscription.test.id
+
+  phase {
+    end_date = "2040-01-01T00:00:00Z"
+
+    item {
+      price = stripe_price.seats.id
+    }
+  }
+
+  phase {
+    item {
+      price = stripe_price.enterprise.id
+    }
+  }
+}
+`
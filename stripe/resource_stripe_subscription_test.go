@@ -0,0 +1,303 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeSubscription_basic(t *testing.T) {
+	var before, after stripe.Subscription
+	var seatsItemID string
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeSubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeSubscriptionConfig(`
+  item {
+    price     = stripe_price.seats.id
+    quantity  = 5
+    tax_rates = [stripe_tax_rate.vat.id]
+  }
+
+  item {
+    price = stripe_price.support.id
+  }
+
+  coupon = stripe_coupon.launch.id
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeSubscriptionExists("stripe_subscription.test", &before),
+					resource.TestCheckResourceAttrPair("stripe_subscription.test", "customer", "stripe_customer.test", "id"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "item.#", "2"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "collection_method", "send_invoice"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "days_until_due", "30"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "coupon", "LAUNCH"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "trial_end", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "status", "trialing"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "metadata.contract", "C-1234"),
+					testAccCheckStripeSubscriptionItem(&before, "stripe_price.seats", 5, &seatsItemID),
+					testAccCheckStripeSubscriptionItem(&before, "stripe_price.support", 1, nil),
+				),
+			},
+			{
+				// Items are updated in place, without recreating the subscription.
+				Config: testAccStripeSubscriptionConfig(`
+  item {
+    price     = stripe_price.seats.id
+    quantity  = 10
+    tax_rates = [stripe_tax_rate.vat.id]
+  }
+
+  item {
+    price    = stripe_price.api_calls.id
+    quantity = 0
+  }
+
+  proration_behavior   = "none"
+  cancel_at_period_end = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeSubscriptionExists("stripe_subscription.test", &after),
+					testAccCheckStripeSubscriptionNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "item.#", "2"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "coupon", ""),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "cancel_at_period_end", "true"),
+					testAccCheckStripeSubscriptionItem(&after, "stripe_price.seats", 10, &seatsItemID),
+					testAccCheckStripeSubscriptionItem(&after, "stripe_price.api_calls", 0, nil),
+				),
+			},
+			{
+				ResourceName:            "stripe_subscription.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"proration_behavior", "invoice_now", "prorate"},
+			},
+		},
+	})
+}
+
+func TestAccStripeSubscription_collectionMethod(t *testing.T) {
+	var before, after stripe.Subscription
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeSubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeSubscriptionConfigCollection(`
+  collection_method = "send_invoice"
+  days_until_due    = 14
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeSubscriptionExists("stripe_subscription.test", &before),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "days_until_due", "14"),
+				),
+			},
+			{
+				// days_until_due is unset when switching back to charging
+				// automatically.
+				Config: testAccStripeSubscriptionConfigCollection(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeSubscriptionExists("stripe_subscription.test", &after),
+					testAccCheckStripeSubscriptionNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "collection_method", "charge_automatically"),
+					resource.TestCheckResourceAttr("stripe_subscription.test", "days_until_due", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStripeSubscriptionExists(n string, subscription *stripe.Subscription) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.Subscriptions.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*subscription = *found
+		return nil
+	}
+}
+
+func testAccCheckStripeSubscriptionNotRecreated(before, after *stripe.Subscription) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID != after.ID {
+			return fmt.Errorf("expected subscription %s to be updated, it was replaced by %s", before.ID, after.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckStripeSubscriptionItem checks the quantity of the subscription's
+// item for the given price. When itemID is set, the item must keep that ID,
+// i.e. it was updated rather than replaced.
+func testAccCheckStripeSubscriptionItem(subscription *stripe.Subscription, price string, quantity int64, itemID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[price]
+		if !ok {
+			return fmt.Errorf("not found: %s", price)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		items, err := resourceStripeSubscriptionListItems(client, "", subscription.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if item.Price.ID != rs.Primary.ID {
+				continue
+			}
+			if item.Quantity != quantity {
+				return fmt.Errorf("expected item %s to have quantity %d, got %d", item.ID, quantity, item.Quantity)
+			}
+			if itemID != nil {
+				if *itemID == "" {
+					*itemID = item.ID
+				} else if *itemID != item.ID {
+					return fmt.Errorf("expected item %s to be updated, it was replaced by %s", *itemID, item.ID)
+				}
+			}
+			return nil
+		}
+
+		return fmt.Errorf("subscription %s has no item for price %s", subscription.ID, rs.Primary.ID)
+	}
+}
+
+// Subscriptions aren't deleted, destroying them cancels them.
+func testAccCheckStripeSubscriptionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_subscription" {
+			continue
+		}
+
+		subscription, err := client.Subscriptions.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if subscription.Status != stripe.SubscriptionStatusCanceled {
+			return fmt.Errorf("subscription %s is still %s", rs.Primary.ID, subscription.Status)
+		}
+	}
+
+	return nil
+}
+
+func testAccStripeSubscriptionConfig(items string) string {
+	return fmt.Sprintf(`
+resource "stripe_customer" "test" {
+  email = "procurement@acme.example"
+  name  = "ACME Corp"
+}
+
+resource "stripe_product" "test" {
+  name = "Platform"
+  type = "service"
+}
+
+resource "stripe_price" "seats" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 2000
+
+  recurring {
+    interval = "month"
+  }
+}
+
+resource "stripe_price" "support" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 50000
+
+  recurring {
+    interval = "month"
+  }
+}
+
+resource "stripe_price" "api_calls" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 1
+
+  recurring {
+    interval   = "month"
+    usage_type = "metered"
+  }
+}
+
+resource "stripe_tax_rate" "vat" {
+  display_name = "VAT"
+  jurisdiction = "DE"
+  percentage   = 19
+  inclusive    = false
+  active       = true
+}
+
+resource "stripe_coupon" "launch" {
+  code        = "LAUNCH"
+  duration    = "forever"
+  percent_off = 15
+}
+
+resource "stripe_subscription" "test" {
+  customer          = stripe_customer.test.id
+  collection_method = "send_invoice"
+  days_until_due    = 30
+  trial_end         = "2030-01-01T00:00:00Z"
+%s
+  metadata = {
+    contract = "C-1234"
+  }
+}
+`, items)
+}
+
+func testAccStripeSubscriptionConfigCollection(collection string) string {
+	return fmt.Sprintf(`
+resource "stripe_customer" "test" {
+  email = "billing@acme.example"
+}
+
+resource "stripe_product" "test" {
+  name = "Invoiced"
+}
+
+resource "stripe_price" "test" {
+  product     = stripe_product.test.id
+  currency    = "usd"
+  unit_amount = 2000
+
+  recurring {
+    interval = "month"
+  }
+}
+
+resource "stripe_subscription" "test" {
+  customer = stripe_customer.test.id
+%s
+  item {
+    price = stripe_price.test.id
+  }
+}
+`, collection)
+}
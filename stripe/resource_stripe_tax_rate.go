@@ -1,12 +1,10 @@
 package stripe
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	stripe "github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 )
 
 func resourceStripeTaxRate() *schema.Resource {
@@ -59,12 +57,21 @@ func resourceStripeTaxRate() *schema.Resource {
 				Type:     schema.TypeFloat,
 				Required: true,
 			},
+			"on_destroy": onDestroySchema(),
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
 		},
 	}
 }
 
 func resourceStripeTaxRateCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
 	taxRateDisplayName := d.Get("display_name").(string)
 	taxRateInclusive := d.Get("inclusive").(bool)
 	taxRatePercentage := d.Get("percentage").(float64)
@@ -74,9 +81,7 @@ func resourceStripeTaxRateCreate(d *schema.ResourceData, m interface{}) error {
 		Percentage:  stripe.Float64(taxRatePercentage),
 	}
 
-	if active, ok := d.GetOk("active"); ok {
-		params.Active = stripe.Bool(active.(bool))
-	}
+	params.Active = stripe.Bool(d.Get("active").(bool))
 
 	if description, ok := d.GetOk("description"); ok {
 		params.Description = stripe.String(description.(string))
@@ -88,11 +93,21 @@ func resourceStripeTaxRateCreate(d *schema.ResourceData, m interface{}) error {
 
 	params.Metadata = expandMetadata(d)
 
-	Tax, err := client.TaxRates.New(params)
+	setStripeAccount(params, account)
+	var Tax *stripe.TaxRate
+	err := createIdempotently(client, "tax_rates", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		Tax, err = client.TaxRates.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return Tax.ID, Tax.LastResponse, nil
+	})
 
 	if err == nil {
 		log.Printf("[INFO] Create Tax Rate: %s (%f)", Tax.ID, Tax.Percentage)
-		d.SetId(Tax.ID)
+		d.SetId(stripeResourceID(account, Tax.ID))
+		d.Set("stripe_account", account)
 		d.Set("display_name", Tax.DisplayName)
 		d.Set("inclusive", Tax.Inclusive)
 		d.Set("percentage", Tax.Percentage)
@@ -104,28 +119,39 @@ func resourceStripeTaxRateCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceStripeTaxRateRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	Tax, err := client.TaxRates.Get(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.TaxRateParams{}
+	setStripeAccount(params, account)
+	Tax, err := client.TaxRates.Get(id, params)
 
 	if err != nil {
 		d.SetId("")
 	} else {
-		d.Set("active", Tax.Active)
-		d.Set("created", Tax.Created)
-		d.Set("description", Tax.Description)
-		d.Set("display_name", Tax.DisplayName)
-		d.Set("inclusive", Tax.Inclusive)
-		d.Set("jurisdiction", Tax.Jurisdiction)
-		d.Set("livemode", Tax.Livemode)
-		d.Set("metadata", Tax.Metadata)
+		d.Set("stripe_account", account)
+		flattenTaxRate(d, Tax)
 	}
 
 	return err
 }
 
+func flattenTaxRate(d *schema.ResourceData, taxRate *stripe.TaxRate) {
+	d.Set("active", taxRate.Active)
+	d.Set("created", taxRate.Created)
+	d.Set("description", taxRate.Description)
+	d.Set("display_name", taxRate.DisplayName)
+	d.Set("inclusive", taxRate.Inclusive)
+	d.Set("jurisdiction", taxRate.Jurisdiction)
+	d.Set("livemode", taxRate.Livemode)
+	d.Set("metadata", taxRate.Metadata)
+	d.Set("percentage", taxRate.Percentage)
+}
+
 func resourceStripeTaxRateUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
 	params := stripe.TaxRateParams{}
+	setStripeAccount(&params, account)
 
 	if d.HasChange("active") {
 		params.Active = stripe.Bool(d.Get("active").(bool))
@@ -135,7 +161,7 @@ func resourceStripeTaxRateUpdate(d *schema.ResourceData, m interface{}) error {
 		params.Description = stripe.String(d.Get("description").(string))
 	}
 
-	if d.HasChange("diplay_name") {
+	if d.HasChange("display_name") {
 		params.DisplayName = stripe.String(d.Get("display_name").(string))
 	}
 
@@ -147,7 +173,7 @@ func resourceStripeTaxRateUpdate(d *schema.ResourceData, m interface{}) error {
 		params.Metadata = expandMetadata(d)
 	}
 
-	_, err := client.TaxRates.Update(d.Id(), &params)
+	_, err := client.TaxRates.Update(id, &params)
 
 	if err != nil {
 		return err
@@ -157,5 +183,14 @@ func resourceStripeTaxRateUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceStripeTaxRateDelete(d *schema.ResourceData, m interface{}) error {
-	return fmt.Errorf("[WARNING] Stripe doesn't allow deleting tax rates via the API.  Your state file contains at least one (\"%v\") that needs deletion.  Please remove it manually.", d.Get("display_name"))
+	return resourceStripeUndeletableDelete(d, m, "tax rate", func() error {
+		client := m.(*Client)
+		account, id := resourceStripeID(d, m)
+		params := &stripe.TaxRateParams{
+			Active: stripe.Bool(false),
+		}
+		setStripeAccount(params, account)
+		_, err := client.TaxRates.Update(id, params)
+		return err
+	})
 }
@@ -0,0 +1,83 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeTaxRate_basic(t *testing.T) {
+	var taxRate stripe.TaxRate
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeTaxRateConfig("VAT", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeTaxRateExists("stripe_tax_rate.test", &taxRate),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "display_name", "VAT"),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "description", "VAT Germany"),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "jurisdiction", "DE"),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "percentage", "19"),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "inclusive", "false"),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "active", "true"),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "metadata.country", "de"),
+					resource.TestCheckResourceAttrSet("stripe_tax_rate.test", "created"),
+				),
+			},
+			{
+				Config: testAccStripeTaxRateConfig("MwSt", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeTaxRateExists("stripe_tax_rate.test", &taxRate),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "display_name", "MwSt"),
+					resource.TestCheckResourceAttr("stripe_tax_rate.test", "active", "false"),
+				),
+			},
+			{
+				ResourceName:      "stripe_tax_rate.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckStripeTaxRateExists(n string, taxRate *stripe.TaxRate) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.TaxRates.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*taxRate = *found
+		return nil
+	}
+}
+
+func testAccStripeTaxRateConfig(displayName string, active bool) string {
+	return fmt.Sprintf(`
+resource "stripe_tax_rate" "test" {
+  display_name = "%s"
+  description  = "VAT Germany"
+  jurisdiction = "DE"
+  percentage   = 19
+  inclusive    = false
+  active       = %t
+
+  metadata = {
+    country = "de"
+  }
+}
+`, displayName, active)
+}
@@ -1,11 +1,12 @@
 package stripe
 
 import (
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	stripe "github.com/stripe/stripe-go/v72"
-	"github.com/stripe/stripe-go/v72/client"
 
 	"log"
+	"net/http"
 )
 
 func resourceStripeWebhookEndpoint() *schema.Resource {
@@ -14,6 +15,9 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 		Read:   resourceStripeWebhookEndpointRead,
 		Update: resourceStripeWebhookEndpointUpdate,
 		Delete: resourceStripeWebhookEndpointDelete,
+		CustomizeDiff: customdiff.ComputedIf("secret", func(d *schema.ResourceDiff, m interface{}) bool {
+			return d.Id() != "" && d.HasChange("rotate_secret_on")
+		}),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -24,8 +28,12 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 				Required: true,
 			},
 			"enabled_events": &schema.Schema{
-				Type:     schema.TypeList,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateWebhookEvent,
+				},
+				Set:      schema.HashString,
 				Required: true,
 			},
 			"connect": &schema.Schema{
@@ -33,81 +41,201 @@ func resourceStripeWebhookEndpoint() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
-			"secret": &schema.Schema{
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"api_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true, // the account's default version
+				ForceNew: true,
+			},
+			"disabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"metadata": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Changing any of these values rolls the endpoint's secret.
+			"rotate_secret_on": &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			// Computed
+			"status": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"stripe_account": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStripeAccount,
+			},
 		},
 	}
 }
 
 func resourceStripeWebhookEndpointCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account := resourceStripeAccount(d, m)
 	url := d.Get("url").(string)
 
 	params := &stripe.WebhookEndpointParams{
 		URL:           stripe.String(url),
-		EnabledEvents: expandStringList(d, "enabled_events"),
+		EnabledEvents: expandStringSet(d.Get("enabled_events").(*schema.Set)),
 	}
 
 	if connect, ok := d.GetOk("connect"); ok {
 		params.Connect = stripe.Bool(connect.(bool))
 	}
 
-	webhookEndpoint, err := client.WebhookEndpoints.New(params)
+	if description, ok := d.GetOk("description"); ok {
+		params.Description = stripe.String(description.(string))
+	}
 
-	if err == nil {
-		log.Printf("[INFO] Create webbook endpoint: %s", url)
-		d.SetId(webhookEndpoint.ID)
-		d.Set("secret", webhookEndpoint.Secret)
+	if apiVersion, ok := d.GetOk("api_version"); ok {
+		params.APIVersion = stripe.String(apiVersion.(string))
 	}
 
-	return err
+	params.Metadata = expandMetadata(d)
+
+	setStripeAccount(params, account)
+	var webhookEndpoint *stripe.WebhookEndpoint
+	err := createIdempotently(client, "webhook_endpoints", params, func() (string, *stripe.APIResponse, error) {
+		var err error
+		webhookEndpoint, err = client.WebhookEndpoints.New(params)
+		if err != nil {
+			return "", nil, err
+		}
+		return webhookEndpoint.ID, webhookEndpoint.LastResponse, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Create webbook endpoint: %s", url)
+	d.SetId(stripeResourceID(account, webhookEndpoint.ID))
+	d.Set("secret", webhookEndpoint.Secret)
+
+	// Endpoints can only be disabled once created.
+	if d.Get("disabled").(bool) {
+		params := &stripe.WebhookEndpointParams{
+			Disabled: stripe.Bool(true),
+		}
+		setStripeAccount(params, account)
+		if _, err := client.WebhookEndpoints.Update(webhookEndpoint.ID, params); err != nil {
+			return err
+		}
+	}
+
+	return resourceStripeWebhookEndpointRead(d, m)
 }
 
 func resourceStripeWebhookEndpointRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	webhookEndpoint, err := client.WebhookEndpoints.Get(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.WebhookEndpointParams{}
+	setStripeAccount(params, account)
+	webhookEndpoint, err := client.WebhookEndpoints.Get(id, params)
 
 	if err != nil {
 		return err
 	}
 
+	d.Set("stripe_account", account)
+	flattenWebhookEndpoint(d, webhookEndpoint)
+
+	return nil
+}
+
+func flattenWebhookEndpoint(d *schema.ResourceData, webhookEndpoint *stripe.WebhookEndpoint) {
 	d.Set("url", webhookEndpoint.URL)
 	d.Set("enabled_events", webhookEndpoint.EnabledEvents)
 	d.Set("connect", webhookEndpoint.Application != "")
-
-	return nil
+	d.Set("description", webhookEndpoint.Description)
+	d.Set("api_version", webhookEndpoint.APIVersion)
+	d.Set("disabled", webhookEndpoint.Status == "disabled")
+	d.Set("metadata", webhookEndpoint.Metadata)
+	d.Set("status", webhookEndpoint.Status)
 }
 
 func resourceStripeWebhookEndpointUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
 	params := stripe.WebhookEndpointParams{}
+	setStripeAccount(&params, account)
 
 	if d.HasChange("url") {
 		params.URL = stripe.String(d.Get("url").(string))
 	}
 
 	if d.HasChange("enabled_events") {
-		params.EnabledEvents = expandStringList(d, "enabled_events")
+		params.EnabledEvents = expandStringSet(d.Get("enabled_events").(*schema.Set))
+	}
+
+	if d.HasChange("description") {
+		params.Description = stripe.String(d.Get("description").(string))
+	}
+
+	if d.HasChange("disabled") {
+		params.Disabled = stripe.Bool(d.Get("disabled").(bool))
 	}
 
-	if d.HasChange("connect") {
-		params.Connect = stripe.Bool(d.Get("connect").(bool))
+	if d.HasChange("metadata") {
+		params.Metadata = expandMetadata(d)
 	}
 
-	_, err := client.WebhookEndpoints.Update(d.Id(), &params)
+	_, err := client.WebhookEndpoints.Update(id, &params)
 
 	if err != nil {
 		return err
 	}
 
+	if d.HasChange("rotate_secret_on") {
+		params := &stripe.WebhookEndpointParams{}
+		setStripeAccount(params, account)
+		webhookEndpoint, err := rollWebhookEndpointSecret(client, id, params)
+		if err != nil {
+			return err
+		}
+		d.Set("secret", webhookEndpoint.Secret)
+	}
+
 	return resourceStripeWebhookEndpointRead(d, m)
 }
 
+// rollWebhookEndpointSecret replaces the endpoint's signing secret. stripe-go
+// doesn't wrap this call, so it goes through the client's backend directly.
+func rollWebhookEndpointSecret(client *Client, id string, params *stripe.WebhookEndpointParams) (*stripe.WebhookEndpoint, error) {
+	path := stripe.FormatURLPath("/v1/webhook_endpoints/%s/roll_secret", id)
+	webhookEndpoint := &stripe.WebhookEndpoint{}
+	err := client.WebhookEndpoints.B.Call(http.MethodPost, path, client.WebhookEndpoints.Key, params, webhookEndpoint)
+	return webhookEndpoint, err
+}
+
 func resourceStripeWebhookEndpointDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.API)
-	_, err := client.WebhookEndpoints.Del(d.Id(), nil)
+	client := m.(*Client)
+	account, id := resourceStripeID(d, m)
+	params := &stripe.WebhookEndpointParams{}
+	setStripeAccount(params, account)
+	_, err := client.WebhookEndpoints.Del(id, params)
 
 	if err == nil {
 		d.SetId("")
@@ -0,0 +1,302 @@
+package stripe
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+func TestAccStripeWebhookEndpoint_basic(t *testing.T) {
+	var webhookEndpoint stripe.WebhookEndpoint
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeWebhookEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeWebhookEndpointConfig("https://example.com/webhook", `"charge.succeeded", "charge.failed"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeWebhookEndpointExists("stripe_webhook_endpoint.test", &webhookEndpoint),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "url", "https://example.com/webhook"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "enabled_events.#", "2"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", testAccStripeWebhookEndpointEvent("charge.succeeded"), "charge.succeeded"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "connect", "false"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "disabled", "false"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "status", "enabled"),
+					resource.TestCheckResourceAttrSet("stripe_webhook_endpoint.test", "api_version"),
+					resource.TestMatchResourceAttr("stripe_webhook_endpoint.test", "secret", regexp.MustCompile("^whsec_")),
+				),
+			},
+			{
+				Config: testAccStripeWebhookEndpointConfig("https://example.com/hooks/stripe", `"source.chargeable"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeWebhookEndpointExists("stripe_webhook_endpoint.test", &webhookEndpoint),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "url", "https://example.com/hooks/stripe"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "enabled_events.#", "1"),
+					resource.TestMatchResourceAttr("stripe_webhook_endpoint.test", "secret", regexp.MustCompile("^whsec_")),
+				),
+			},
+			{
+				// Reordering events doesn't cause a diff.
+				Config:   testAccStripeWebhookEndpointConfig("https://example.com/hooks/stripe", `"source.chargeable"`),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "stripe_webhook_endpoint.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The secret is only returned when the endpoint is created.
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+		},
+	})
+}
+
+func TestAccStripeWebhookEndpoint_connect(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeWebhookEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "stripe_webhook_endpoint" "test" {
+  url            = "https://example.com/connect"
+  enabled_events = ["account.updated"]
+  connect        = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "connect", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripeWebhookEndpoint_settings(t *testing.T) {
+	var before, after stripe.WebhookEndpoint
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeWebhookEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeWebhookEndpointSettingsConfig(`"invoice.paid", "invoice.payment_failed"`, "Billing", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeWebhookEndpointExists("stripe_webhook_endpoint.test", &before),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "description", "Billing"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "api_version", "2020-08-27"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "disabled", "true"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "status", "disabled"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "metadata.team", "billing"),
+				),
+			},
+			{
+				// Reordering events doesn't cause a diff.
+				Config:   testAccStripeWebhookEndpointSettingsConfig(`"invoice.payment_failed", "invoice.paid"`, "Billing", true),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccStripeWebhookEndpointSettingsConfig(`"*"`, "Everything", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeWebhookEndpointExists("stripe_webhook_endpoint.test", &after),
+					testAccCheckStripeWebhookEndpointNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "enabled_events.#", "1"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", testAccStripeWebhookEndpointEvent("*"), "*"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "description", "Everything"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "disabled", "false"),
+					resource.TestCheckResourceAttr("stripe_webhook_endpoint.test", "status", "enabled"),
+				),
+			},
+			{
+				ResourceName:            "stripe_webhook_endpoint.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+		},
+	})
+}
+
+func TestAccStripeWebhookEndpoint_rotateSecret(t *testing.T) {
+	var before, after stripe.WebhookEndpoint
+	var secret string
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStripeWebhookEndpointDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStripeWebhookEndpointRotateConfig("https://example.com/webhook", "2022-10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeWebhookEndpointExists("stripe_webhook_endpoint.test", &before),
+					testAccCheckStripeWebhookEndpointSecret("stripe_webhook_endpoint.test", &secret, false),
+				),
+			},
+			{
+				// Other changes keep the secret.
+				Config: testAccStripeWebhookEndpointRotateConfig("https://example.com/hooks", "2022-10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeWebhookEndpointSecret("stripe_webhook_endpoint.test", &secret, false),
+				),
+			},
+			{
+				Config: testAccStripeWebhookEndpointRotateConfig("https://example.com/hooks", "2022-11"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStripeWebhookEndpointExists("stripe_webhook_endpoint.test", &after),
+					testAccCheckStripeWebhookEndpointNotRecreated(&before, &after),
+					testAccCheckStripeWebhookEndpointSecret("stripe_webhook_endpoint.test", &secret, true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStripeWebhookEndpoint_unknownEvent(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccStripeWebhookEndpointConfig("https://example.com/webhook", `"charge.succeded"`),
+				ExpectError: regexp.MustCompile(`"charge.succeded" is not a known Stripe event`),
+			},
+		},
+	})
+}
+
+func TestValidateWebhookEvent(t *testing.T) {
+	for _, event := range []string{"*", "charge.succeeded"} {
+		if _, errors := validateWebhookEvent(event, "enabled_events.0"); len(errors) != 0 {
+			t.Fatalf("expected %q to be valid, got %v", event, errors)
+		}
+	}
+
+	_, errors := validateWebhookEvent("charge.succeded", "enabled_events.0")
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), `"charge.succeded" is not a known Stripe event`) {
+		t.Fatalf("expected an unknown event to be rejected, got %v", errors)
+	}
+}
+
+func testAccCheckStripeWebhookEndpointExists(n string, webhookEndpoint *stripe.WebhookEndpoint) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		found, err := client.WebhookEndpoints.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		*webhookEndpoint = *found
+		return nil
+	}
+}
+
+func testAccCheckStripeWebhookEndpointNotRecreated(before, after *stripe.WebhookEndpoint) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID != after.ID {
+			return fmt.Errorf("expected webhook endpoint %s to be updated, it was replaced by %s", before.ID, after.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckStripeWebhookEndpointSecret checks whether the endpoint's secret
+// was rolled since the last check.
+func testAccCheckStripeWebhookEndpointSecret(n string, secret *string, rolled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		current := rs.Primary.Attributes["secret"]
+		if !strings.HasPrefix(current, "whsec_") {
+			return fmt.Errorf("webhook endpoint %s has no secret", rs.Primary.ID)
+		}
+		if *secret != "" {
+			if rolled && current == *secret {
+				return fmt.Errorf("expected the secret of webhook endpoint %s to be rolled", rs.Primary.ID)
+			}
+			if !rolled && current != *secret {
+				return fmt.Errorf("expected the secret of webhook endpoint %s to be kept", rs.Primary.ID)
+			}
+		}
+
+		*secret = current
+		return nil
+	}
+}
+
+func testAccCheckStripeWebhookEndpointDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "stripe_webhook_endpoint" {
+			continue
+		}
+
+		if _, err := client.WebhookEndpoints.Get(rs.Primary.ID, nil); err == nil {
+			return fmt.Errorf("webhook endpoint %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStripeWebhookEndpointConfig(url, enabledEvents string) string {
+	return fmt.Sprintf(`
+resource "stripe_webhook_endpoint" "test" {
+  url            = "%s"
+  enabled_events = [%s]
+}
+`, url, enabledEvents)
+}
+
+func testAccStripeWebhookEndpointSettingsConfig(enabledEvents, description string, disabled bool) string {
+	return fmt.Sprintf(`
+resource "stripe_webhook_endpoint" "test" {
+  url            = "https://example.com/billing"
+  enabled_events = [%s]
+  description    = "%s"
+  api_version    = "2020-08-27"
+  disabled       = %t
+
+  metadata = {
+    team = "billing"
+  }
+}
+`, enabledEvents, description, disabled)
+}
+
+func testAccStripeWebhookEndpointRotateConfig(url, rotation string) string {
+	return fmt.Sprintf(`
+resource "stripe_webhook_endpoint" "test" {
+  url            = "%s"
+  enabled_events = ["charge.succeeded"]
+
+  rotate_secret_on = {
+    rotation = "%s"
+  }
+}
+`, url, rotation)
+}
+
+// testAccStripeWebhookEndpointEvent returns the state key of an enabled event.
+func testAccStripeWebhookEndpointEvent(event string) string {
+	return fmt.Sprintf("enabled_events.%d", hashcode.String(event))
+}
@@ -0,0 +1,217 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-go/v72"
+)
+
+// retryTransport retries the requests Stripe's backends send, in place of
+// stripe-go's own retries whose backoff can't be configured and which give up
+// on rate limited requests. The idempotency key of POST requests is kept
+// across attempts, so that retrying a request that did go through doesn't
+// create a second object. Responses asking to retry after some time are waited
+// for at least that long.
+type retryTransport struct {
+	next http.RoundTripper
+
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	// timeout bounds each attempt rather than the whole request, so that
+	// retries and the time spent waiting between them don't count towards
+	// it.
+	timeout time.Duration
+
+	// limiter spaces requests out when set.
+	limiter *rateLimiter
+
+	sleep func(time.Duration)
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Round trippers must not modify the request they're given.
+	clone := *req
+	clone.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		clone.Header[k] = v
+	}
+	req = &clone
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	if req.Method == http.MethodPost && req.Header.Get("Idempotency-Key") == "" {
+		req.Header.Set("Idempotency-Key", stripe.NewIdempotencyKey())
+	}
+
+	for retry := 0; ; retry++ {
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		if t.limiter != nil {
+			t.limiter.wait(t.sleep)
+		}
+
+		attempt, cancel := req, context.CancelFunc(func() {})
+		if t.timeout > 0 {
+			var ctx context.Context
+			ctx, cancel = context.WithTimeout(req.Context(), t.timeout)
+			attempt = req.WithContext(ctx)
+		}
+
+		resp, err := t.next.RoundTrip(attempt)
+		if retry >= t.maxRetries || !shouldRetry(req, resp, err) {
+			if resp == nil {
+				cancel()
+				return resp, err
+			}
+			// The body is read after the attempt returns.
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, err
+		}
+
+		delay := t.backoff(retry)
+		if resp != nil {
+			if wait := retryAfter(resp); wait > delay {
+				delay = wait
+			}
+
+			// Drain the body so that the connection can be reused.
+			ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		cancel()
+
+		log.Printf("[WARN] Retrying %s %s in %s (retry %d of %d)", req.Method, req.URL.Path, delay, retry+1, t.maxRetries)
+		t.sleep(delay)
+	}
+}
+
+// backoff grows exponentially from minBackoff up to maxBackoff, with some
+// jitter so that concurrent requests don't all retry at once.
+func (t *retryTransport) backoff(retry int) time.Duration {
+	delay := t.minBackoff
+	for i := 0; i < retry && delay < t.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > t.maxBackoff {
+		delay = t.maxBackoff
+	}
+
+	if jitter := int64(delay / 4); jitter > 0 {
+		delay -= time.Duration(rand.Int63n(jitter))
+	}
+	if delay < t.minBackoff {
+		delay = t.minBackoff
+	}
+	return delay
+}
+
+// retryAfter returns how long the `Retry-After` header of a response asks to
+// wait before retrying, if at all.
+func retryAfter(resp *http.Response) time.Duration {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// cancelBody releases the context of the attempt a response body was read
+// from once it's closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// shouldRetry follows stripe-go's rules, with the addition of rate limited
+// requests: Stripe's `Stripe-Should-Retry` header has the final say, then
+// conflicts, rate limits and unavailability are retried, as are server
+// errors for requests that aren't covered by an idempotency key.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch resp.Header.Get("Stripe-Should-Retry") {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusConflict,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return req.Method != http.MethodPost
+	}
+	return false
+}
+
+// rateLimiter spaces requests out evenly to stay under a number of requests
+// per second.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond int) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Second / time.Duration(requestsPerSecond)}
+}
+
+// wait blocks until the next request is allowed.
+func (l *rateLimiter) wait(sleep func(time.Duration)) {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay > 0 {
+		sleep(delay)
+	}
+}
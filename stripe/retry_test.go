@@ -0,0 +1,270 @@
+package stripe
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+// testRetryServer answers requests with the given statuses in turn, then
+// with a product, and records the requests it received.
+type testRetryServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	headers  []http.Header
+	bodies   []string
+}
+
+func newTestRetryServer(statuses []int, header http.Header) *testRetryServer {
+	s := &testRetryServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.headers = append(s.headers, r.Header)
+		s.bodies = append(s.bodies, string(body))
+
+		if len(s.statuses) > 0 {
+			status := s.statuses[0]
+			s.statuses = s.statuses[1:]
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error": {"type": "api_error", "message": "try again"}}`))
+			return
+		}
+
+		w.Write([]byte(`{"id": "prod_retried", "object": "product", "name": "Retried"}`))
+	}))
+	return s
+}
+
+func testRetryConfig(url string, maxNetworkRetries int) Config {
+	return Config{
+		APIToken:          "sk_test_mock",
+		APIBase:           url,
+		MaxNetworkRetries: maxNetworkRetries,
+		RetryMinBackoff:   time.Millisecond,
+		RetryMaxBackoff:   5 * time.Millisecond,
+	}
+}
+
+func TestConfigClient_retries(t *testing.T) {
+	server := newTestRetryServer([]int{http.StatusTooManyRequests, http.StatusConflict}, nil)
+	defer server.Close()
+
+	config := testRetryConfig(server.URL, 2)
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	product, err := client.Products.New(&stripe.ProductParams{
+		Name: stripe.String("Retried"),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if product.ID != "prod_retried" {
+		t.Fatalf("expected product prod_retried, got %s", product.ID)
+	}
+
+	if len(server.headers) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(server.headers))
+	}
+
+	key := server.headers[0].Get("Idempotency-Key")
+	if key == "" {
+		t.Fatal("expected requests to have an idempotency key")
+	}
+	for i := range server.headers {
+		if got := server.headers[i].Get("Idempotency-Key"); got != key {
+			t.Fatalf("expected retry %d to reuse idempotency key %s, got %s", i, key, got)
+		}
+		if server.bodies[i] != server.bodies[0] {
+			t.Fatalf("expected retry %d to send %q, got %q", i, server.bodies[0], server.bodies[i])
+		}
+	}
+}
+
+func TestConfigClient_retriesExhausted(t *testing.T) {
+	server := newTestRetryServer([]int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}, nil)
+	defer server.Close()
+
+	config := testRetryConfig(server.URL, 1)
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := client.Products.New(&stripe.ProductParams{Name: stripe.String("Retried")}); err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if len(server.headers) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(server.headers))
+	}
+}
+
+func TestConfigClient_shouldRetryHeader(t *testing.T) {
+	server := newTestRetryServer([]int{http.StatusServiceUnavailable}, http.Header{
+		"Stripe-Should-Retry": []string{"false"},
+	})
+	defer server.Close()
+
+	config := testRetryConfig(server.URL, 2)
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := client.Products.New(&stripe.ProductParams{Name: stripe.String("Retried")}); err == nil {
+		t.Fatal("expected Stripe-Should-Retry: false not to be retried")
+	}
+	if len(server.headers) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(server.headers))
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	cases := []struct {
+		method string
+		status int
+		header string
+		retry  bool
+	}{
+		{http.MethodPost, http.StatusConflict, "", true},
+		{http.MethodPost, http.StatusTooManyRequests, "", true},
+		{http.MethodPost, http.StatusServiceUnavailable, "", true},
+		{http.MethodPost, http.StatusInternalServerError, "", false},
+		{http.MethodGet, http.StatusInternalServerError, "", true},
+		{http.MethodPost, http.StatusBadRequest, "", false},
+		{http.MethodPost, http.StatusBadRequest, "true", true},
+		{http.MethodGet, http.StatusTooManyRequests, "false", false},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, "https://api.stripe.com/v1/products", nil)
+		resp := &http.Response{StatusCode: c.status, Header: http.Header{}}
+		if c.header != "" {
+			resp.Header.Set("Stripe-Should-Retry", c.header)
+		}
+
+		if got := shouldRetry(req, resp, nil); got != c.retry {
+			t.Errorf("%s %d (Stripe-Should-Retry: %q): expected retry to be %t", c.method, c.status, c.header, c.retry)
+		}
+	}
+}
+
+func TestRetryTransport_attemptTimeout(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// The first attempt hangs until it's given up on.
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"id": "prod_retried", "object": "product", "name": "Retried"}`))
+	}))
+	defer server.Close()
+
+	// The whole request takes longer than an attempt is allowed to.
+	var slept time.Duration
+	client := &http.Client{Transport: &retryTransport{
+		next:       http.DefaultTransport,
+		maxRetries: 1,
+		minBackoff: 200 * time.Millisecond,
+		maxBackoff: 200 * time.Millisecond,
+		timeout:    100 * time.Millisecond,
+		sleep:      func(d time.Duration) { slept += d; time.Sleep(d) },
+	}}
+
+	resp, err := client.Get(server.URL + "/v1/products/prod_retried")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected the body to be readable after the attempt returned, got %s", err)
+	}
+	if !strings.Contains(string(body), "prod_retried") {
+		t.Fatalf("unexpected body %s", body)
+	}
+	if atomic.LoadInt32(&requests) != 2 || slept < 200*time.Millisecond {
+		t.Fatalf("expected the first attempt to time out and be retried, got %d requests", atomic.LoadInt32(&requests))
+	}
+}
+
+func TestRetryTransport_retryAfter(t *testing.T) {
+	server := newTestRetryServer([]int{http.StatusTooManyRequests}, http.Header{
+		"Retry-After": []string{"3"},
+	})
+	defer server.Close()
+
+	var slept []time.Duration
+	client := &http.Client{Transport: &retryTransport{
+		next:       http.DefaultTransport,
+		maxRetries: 1,
+		minBackoff: time.Millisecond,
+		maxBackoff: 5 * time.Millisecond,
+		sleep:      func(d time.Duration) { slept = append(slept, d) },
+	}}
+
+	resp, err := client.Get(server.URL + "/v1/products/prod_retried")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if len(slept) != 1 || slept[0] != 3*time.Second {
+		t.Fatalf("expected to wait 3s as asked by Retry-After, waited %v", slept)
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	transport := &retryTransport{minBackoff: 100 * time.Millisecond, maxBackoff: time.Second}
+
+	for retry, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		min := max * 3 / 4
+		if min < transport.minBackoff {
+			min = transport.minBackoff
+		}
+
+		if got := transport.backoff(retry); got < min || got > max {
+			t.Errorf("retry %d: expected a backoff between %s and %s, got %s", retry, min, max, got)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	if newRateLimiter(0) != nil {
+		t.Fatal("expected no limiter without a rate")
+	}
+
+	limiter := newRateLimiter(4)
+
+	var waited time.Duration
+	sleep := func(d time.Duration) { waited += d }
+	for i := 0; i < 5; i++ {
+		limiter.wait(sleep)
+	}
+
+	// The first request goes through right away, the next four are spaced
+	// by 250ms.
+	if expected := 2500 * time.Millisecond; waited < expected-100*time.Millisecond || waited > expected {
+		t.Fatalf("expected to wait about %s, waited %s", expected, waited)
+	}
+}
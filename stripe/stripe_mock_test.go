@@ -0,0 +1,1632 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v72"
+)
+
+// stripeMock is a small in-memory stand-in for stripe-mock. It understands
+// the form encoding used by stripe-go and keeps enough state to exercise
+// the create/read/update/delete/list paths of the provider's resources.
+//
+// Parameters are decoded into nested maps and coerced into properly typed
+// JSON values by walking the stripe-go model of each collection, so that
+// adding a collection is mostly a matter of declaring it.
+type stripeMock struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	seq         int64
+	collections map[string]*mockCollection
+	objects     map[string]map[string]map[string]interface{}
+	order       map[string][]string
+	requests    []*http.Request
+
+	// accounts records the connected account (the Stripe-Account header)
+	// each object was created on, keyed by "<collection>/<id>". Objects
+	// are only visible to requests made on behalf of the same account.
+	accounts map[string]string
+	// account is the connected account of the request being served, for
+	// hooks creating related objects.
+	account string
+
+	// idempotent records the successful responses to POST requests, keyed by
+	// account and idempotency key, so that they're replayed like Stripe does.
+	idempotent map[string]*mockResponse
+	// loseResponses is how many of the next POST requests are processed but
+	// answered with an error, as if the connection had been lost.
+	loseResponses int
+}
+
+// mockResponse is a response recorded for an idempotency key, along with the
+// request it answered.
+type mockResponse struct {
+	request string
+	status  int
+	body    []byte
+}
+
+type mockCollection struct {
+	// object is the value of the `object` attribute, e.g. "price".
+	object string
+	// prefix is prepended to generated IDs.
+	prefix string
+	// model is the stripe-go type objects of this collection decode into.
+	model interface{}
+	// defaults returns the attributes set on every new object.
+	defaults func() map[string]interface{}
+	// write derives server-side attributes after a create or an update.
+	write func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error
+	// expandOnly lists attributes only returned when explicitly expanded.
+	expandOnly []string
+	// createOnly lists attributes only returned in the create response and
+	// by actions.
+	createOnly []string
+	// actions handles POST /v1/<collection>/<id>/<action> calls.
+	actions map[string]func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error
+	// deletable reports whether DELETE is supported.
+	deletable bool
+	// destroy replaces removing deletable objects, e.g. to cancel them.
+	destroy func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error
+	// view derives attributes from other objects when rendering.
+	view func(m *stripeMock, obj map[string]interface{})
+	// parent is the attribute holding the ID of the object a nested
+	// collection, e.g. "customers/*/tax_ids", belongs to.
+	parent string
+	// ascending lists objects oldest first, e.g. line items.
+	ascending bool
+}
+
+// mockError is rendered as a Stripe API error.
+type mockError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *mockError) Error() string {
+	return e.message
+}
+
+func mockInvalidRequest(format string, args ...interface{}) error {
+	return &mockError{status: http.StatusBadRequest, code: "parameter_invalid", message: fmt.Sprintf(format, args...)}
+}
+
+func newStripeMock() *stripeMock {
+	m := &stripeMock{
+		collections: map[string]*mockCollection{},
+		objects:     map[string]map[string]map[string]interface{}{},
+		order:       map[string][]string{},
+		accounts:    map[string]string{},
+		idempotent:  map[string]*mockResponse{},
+	}
+
+	m.register("products", &mockCollection{
+		object: "product",
+		prefix: "prod_",
+		model:  stripe.Product{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"active":     true,
+				"attributes": []interface{}{},
+				"features":   []interface{}{},
+				"images":     []interface{}{},
+				"type":       "service",
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			for _, k := range []string{"features", "images"} {
+				if obj[k] == "" {
+					obj[k] = []interface{}{}
+				}
+			}
+			for _, k := range []string{"default_price", "package_dimensions", "tax_code"} {
+				if obj[k] == "" {
+					obj[k] = nil
+				}
+			}
+			if created {
+				mockSetDefault(obj, "shippable", obj["type"] == "good")
+			}
+			if id, ok := obj["default_price"].(string); ok {
+				price, ok := m.objects["prices"][id]
+				if !ok || price["product"] != obj["id"] {
+					return mockInvalidRequest("The price %s does not belong to product %s", id, obj["id"])
+				}
+			}
+			return nil
+		},
+		deletable: true,
+	})
+
+	m.register("prices", &mockCollection{
+		object: "price",
+		prefix: "price_",
+		model:  stripe.Price{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"active":         true,
+				"billing_scheme": "per_unit",
+				"tax_behavior":   "unspecified",
+				"type":           "one_time",
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			if old, ok := m.objects["prices"][obj["id"].(string)]; ok && old["tax_behavior"] != "unspecified" && old["tax_behavior"] != obj["tax_behavior"] {
+				return mockInvalidRequest("The tax behavior of a price can't be changed once it has been set to inclusive or exclusive.")
+			}
+			if customUnitAmount, ok := obj["custom_unit_amount"].(map[string]interface{}); ok {
+				delete(customUnitAmount, "enabled")
+			}
+			if recurring, ok := obj["recurring"].(map[string]interface{}); ok {
+				obj["type"] = "recurring"
+				mockSetDefault(recurring, "interval_count", int64(1))
+				mockSetDefault(recurring, "usage_type", "licensed")
+				if recurring["usage_type"] == "metered" {
+					mockSetDefault(recurring, "aggregate_usage", "sum")
+				}
+			}
+			if unitAmount, ok := obj["unit_amount"].(int64); ok {
+				obj["unit_amount_decimal"] = strconv.FormatInt(unitAmount, 10)
+			}
+			if err := mockDeriveTiers(obj); err != nil {
+				return err
+			}
+
+			// Lookup keys are unique, unless transferred from another price.
+			transfer := obj["transfer_lookup_key"] == "true"
+			delete(obj, "transfer_lookup_key")
+			if lookupKey, ok := obj["lookup_key"].(string); ok && lookupKey != "" {
+				for id, other := range m.objects["prices"] {
+					if id == obj["id"] || other["lookup_key"] != lookupKey {
+						continue
+					}
+					if !transfer {
+						return mockInvalidRequest("A price (`%s`) already uses that lookup key.", id)
+					}
+					delete(other, "lookup_key")
+				}
+			} else {
+				delete(obj, "lookup_key")
+			}
+
+			options := mockCurrencyOptions(obj)
+			for _, o := range options {
+				option := o.(map[string]interface{})
+				if unitAmount, ok := option["unit_amount"].(int64); ok {
+					option["unit_amount_decimal"] = strconv.FormatInt(unitAmount, 10)
+				}
+				mockSetDefault(option, "tax_behavior", "unspecified")
+				if err := mockDeriveTiers(option); err != nil {
+					return err
+				}
+			}
+			options[obj["currency"].(string)] = map[string]interface{}{
+				"unit_amount":         obj["unit_amount"],
+				"unit_amount_decimal": obj["unit_amount_decimal"],
+				"tax_behavior":        "unspecified",
+			}
+			return nil
+		},
+		expandOnly: []string{"tiers", "currency_options"},
+	})
+
+	m.register("plans", &mockCollection{
+		object: "plan",
+		prefix: "plan_",
+		model:  stripe.Plan{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"active":         true,
+				"billing_scheme": "per_unit",
+				"interval_count": int64(1),
+				"usage_type":     "licensed",
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			if amount, ok := obj["amount"].(int64); ok {
+				obj["amount_decimal"] = strconv.FormatInt(amount, 10)
+			}
+			return mockDeriveTiers(obj)
+		},
+		expandOnly: []string{"tiers"},
+		deletable:  true,
+	})
+
+	m.register("coupons", &mockCollection{
+		object: "coupon",
+		prefix: "",
+		model:  stripe.Coupon{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"times_redeemed": int64(0),
+				"valid":          true,
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			options := mockCurrencyOptions(obj)
+			if currency, ok := obj["currency"].(string); ok {
+				options[currency] = map[string]interface{}{
+					"amount_off": obj["amount_off"],
+				}
+			}
+			return nil
+		},
+		expandOnly: []string{"applies_to", "currency_options"},
+		deletable:  true,
+	})
+
+	m.register("promotion_codes", &mockCollection{
+		object: "promotion_code",
+		prefix: "promo_",
+		model:  stripe.PromotionCode{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"active":         true,
+				"times_redeemed": int64(0),
+				"restrictions": map[string]interface{}{
+					"first_time_transaction": false,
+				},
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			if !created {
+				return nil
+			}
+			id, _ := obj["coupon"].(string)
+			coupon, ok := m.objects["coupons"][id]
+			if !ok {
+				return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such coupon: '%s'", id)}
+			}
+			obj["coupon"] = mockCopy(coupon)
+			if code, _ := obj["code"].(string); code == "" {
+				obj["code"] = strings.ToUpper(mockRandomString(8))
+			}
+			return nil
+		},
+	})
+
+	m.register("customers", &mockCollection{
+		object: "customer",
+		prefix: "cus_",
+		model:  stripe.Customer{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"balance":           int64(0),
+				"delinquent":        false,
+				"preferred_locales": []interface{}{},
+				"tax_exempt":        "none",
+				"invoice_settings": map[string]interface{}{
+					"custom_fields":          nil,
+					"default_payment_method": nil,
+					"footer":                 nil,
+					"rendering_options":      nil,
+				},
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			// Nested attributes are merged rather than replaced.
+			if old, ok := m.objects["customers"][obj["id"].(string)]; ok {
+				if invoiceSettings, ok := params["invoice_settings"].(map[string]interface{}); ok {
+					merged := mockCopy(old["invoice_settings"]).(map[string]interface{})
+					mockMerge(merged, mockCoerce(invoiceSettings, reflect.TypeOf(stripe.CustomerInvoiceSettings{}), false).(map[string]interface{}))
+					obj["invoice_settings"] = merged
+				}
+			}
+			return nil
+		},
+		deletable: true,
+	})
+
+	m.register("customers/*/tax_ids", &mockCollection{
+		object: "tax_id",
+		prefix: "txi_",
+		model:  stripe.TaxID{},
+		parent: "customer",
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			if _, ok := m.objects["customers"][obj["customer"].(string)]; !ok {
+				return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such customer: '%s'", obj["customer"])}
+			}
+			taxIDType, _ := obj["type"].(string)
+			value, _ := obj["value"].(string)
+			if taxIDType == "" || value == "" {
+				return mockInvalidRequest("Missing required param: type and value.")
+			}
+			obj["country"] = strings.ToUpper(strings.Split(taxIDType, "_")[0])
+			if taxIDType == "eu_vat" && len(value) > 2 {
+				obj["country"] = strings.ToUpper(value[:2])
+			}
+			obj["verification"] = map[string]interface{}{
+				"status": "pending",
+			}
+			return nil
+		},
+		deletable: true,
+	})
+
+	m.register("shipping_rates", &mockCollection{
+		object: "shipping_rate",
+		prefix: "shr_",
+		model:  stripe.ShippingRate{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"active":            true,
+				"delivery_estimate": nil,
+				"tax_behavior":      "unspecified",
+				"tax_code":          nil,
+				"type":              "fixed_amount",
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			if old, ok := m.objects["shipping_rates"][obj["id"].(string)]; ok {
+				if old["tax_behavior"] != "unspecified" && old["tax_behavior"] != obj["tax_behavior"] {
+					return mockInvalidRequest("The tax behavior of a shipping rate can't be changed once it has been set to inclusive or exclusive.")
+				}
+				// Only the currency options of the amount can be updated.
+				fixedAmount := mockCopy(old["fixed_amount"]).(map[string]interface{})
+				if update, ok := obj["fixed_amount"].(map[string]interface{}); ok {
+					fixedAmount["currency_options"] = update["currency_options"]
+				}
+				obj["fixed_amount"] = fixedAmount
+			}
+
+			fixedAmount, ok := obj["fixed_amount"].(map[string]interface{})
+			if !ok {
+				return mockInvalidRequest("Missing required param: fixed_amount.")
+			}
+			options := mockCurrencyOptions(fixedAmount)
+			for _, o := range options {
+				mockSetDefault(o.(map[string]interface{}), "tax_behavior", "unspecified")
+			}
+			options[fixedAmount["currency"].(string)] = map[string]interface{}{
+				"amount":       fixedAmount["amount"],
+				"tax_behavior": obj["tax_behavior"],
+			}
+			return nil
+		},
+	})
+
+	m.register("payment_links", &mockCollection{
+		object: "payment_link",
+		prefix: "plink_",
+		model:  stripe.PaymentLink{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"active": true,
+				"after_completion": map[string]interface{}{
+					"type":                "hosted_confirmation",
+					"hosted_confirmation": map[string]interface{}{"custom_message": nil},
+				},
+				"allow_promotion_codes":       false,
+				"automatic_tax":               map[string]interface{}{"enabled": false},
+				"billing_address_collection":  "auto",
+				"custom_fields":               []interface{}{},
+				"phone_number_collection":     map[string]interface{}{"enabled": false},
+				"shipping_address_collection": nil,
+				"subscription_data":           nil,
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			// Line items are only listed through their own endpoint.
+			items, _ := obj["line_items"].([]interface{})
+			delete(obj, "line_items")
+			if created {
+				obj["url"] = "https://buy.stripe.com/test_" + mockRandomString(14)
+				if len(items) == 0 {
+					return mockInvalidRequest("Missing required param: line_items.")
+				}
+
+				// Links selling recurring prices have subscription data.
+				for _, item := range items {
+					price := m.objects["prices"][item.(map[string]interface{})["price"].(string)]
+					if price != nil && price["recurring"] != nil && obj["subscription_data"] == nil {
+						obj["subscription_data"] = map[string]interface{}{"trial_period_days": nil}
+					}
+				}
+			}
+			// Line items that are sent replace the existing ones: the ones
+			// with an ID are updated, the others are added.
+			kept := map[string]bool{}
+			for _, item := range items {
+				itemParams := mockCopy(item).(map[string]interface{})
+				id, _ := itemParams["id"].(string)
+				if id == "" {
+					if _, ok := itemParams["price"]; !ok {
+						return mockInvalidRequest("Missing required param: line_items[][price].")
+					}
+					itemParams["payment_link"] = obj["id"]
+					added, err := m.create("payment_links/*/line_items", m.account, itemParams)
+					if err != nil {
+						return err
+					}
+					kept[added["id"].(string)] = true
+					continue
+				}
+				delete(itemParams, "id")
+				existing, ok := m.objects["payment_links/*/line_items"][id]
+				if !ok || existing["payment_link"] != obj["id"] {
+					return mockInvalidRequest("No such line item: '%s'", id)
+				}
+				if _, ok := itemParams["price"]; ok {
+					return mockInvalidRequest("The price of a line item can't be updated.")
+				}
+				if err := m.update("payment_links/*/line_items", existing, itemParams); err != nil {
+					return err
+				}
+				kept[id] = true
+			}
+			if !created && len(items) > 0 {
+				for id, existing := range m.objects["payment_links/*/line_items"] {
+					if existing["payment_link"] == obj["id"] && !kept[id] {
+						m.remove("payment_links/*/line_items", id)
+					}
+				}
+			}
+
+			switch fields := obj["custom_fields"].(type) {
+			case []interface{}:
+				for _, f := range fields {
+					field := f.(map[string]interface{})
+					field["optional"] = field["optional"] == "true"
+					mockSetDefault(field, "dropdown", nil)
+				}
+			default:
+				obj["custom_fields"] = []interface{}{}
+			}
+			return nil
+		},
+	})
+
+	m.register("payment_links/*/line_items", &mockCollection{
+		object: "item",
+		prefix: "li_",
+		model:  stripe.LineItem{},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			if id, ok := obj["price"].(string); ok {
+				price, ok := m.objects["prices"][id]
+				if !ok {
+					return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such price: '%s'", id)}
+				}
+				obj["price"] = mockCopy(price)
+				obj["currency"] = price["currency"]
+			}
+			mockSetDefault(obj, "quantity", int64(1))
+
+			// The SDK doesn't model adjustable quantities, coerce them here.
+			if adjustableQuantity, ok := obj["adjustable_quantity"].(map[string]interface{}); ok {
+				enabled := adjustableQuantity["enabled"] == "true" || adjustableQuantity["enabled"] == true
+				if !enabled {
+					delete(obj, "adjustable_quantity")
+				} else {
+					for _, k := range []string{"minimum", "maximum"} {
+						if v, ok := adjustableQuantity[k].(string); ok {
+							adjustableQuantity[k], _ = strconv.ParseInt(v, 10, 64)
+						}
+					}
+					adjustableQuantity["enabled"] = true
+					mockSetDefault(adjustableQuantity, "minimum", int64(0))
+					mockSetDefault(adjustableQuantity, "maximum", int64(99))
+				}
+			}
+			return nil
+		},
+		parent:    "payment_link",
+		ascending: true,
+	})
+
+	m.register("subscriptions", &mockCollection{
+		object: "subscription",
+		prefix: "sub_",
+		model:  stripe.Subscription{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"cancel_at_period_end": false,
+				"collection_method":    "charge_automatically",
+				"default_tax_rates":    []interface{}{},
+				"status":               "active",
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			delete(obj, "proration_behavior")
+			if obj["collection_method"] != "send_invoice" && obj["days_until_due"] != nil {
+				return mockInvalidRequest("`days_until_due` can only be set when `collection_method` is `send_invoice`.")
+			}
+			if created {
+				if _, ok := m.objects["customers"][obj["customer"].(string)]; !ok {
+					return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such customer: '%s'", obj["customer"])}
+				}
+				mockSetDefault(obj, "billing_cycle_anchor", obj["created"])
+				obj["current_period_start"] = obj["created"]
+				obj["current_period_end"] = obj["created"].(int64) + 30*24*60*60
+				if trialEnd, ok := obj["trial_end"].(int64); ok && trialEnd > m.now() {
+					obj["status"] = "trialing"
+				}
+
+				items, _ := obj["items"].([]interface{})
+				delete(obj, "items")
+				if len(items) == 0 {
+					return mockInvalidRequest("Missing required param: items.")
+				}
+				for _, item := range items {
+					itemParams := mockCopy(item).(map[string]interface{})
+					itemParams["subscription"] = obj["id"]
+					if _, err := m.create("subscription_items", m.account, itemParams); err != nil {
+						return err
+					}
+				}
+			}
+
+			if coupon, ok := params["coupon"].(string); ok {
+				obj["discount"] = nil
+				if coupon != "" {
+					c, ok := m.objects["coupons"][coupon]
+					if !ok {
+						return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such coupon: '%s'", coupon)}
+					}
+					obj["discount"] = map[string]interface{}{"object": "discount", "coupon": mockCopy(c)}
+				}
+			}
+			if promotionCode, ok := params["promotion_code"].(string); ok {
+				p, ok := m.objects["promotion_codes"][promotionCode]
+				if !ok {
+					return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such promotion code: '%s'", promotionCode)}
+				}
+				obj["discount"] = map[string]interface{}{"object": "discount", "coupon": mockCopy(p["coupon"]), "promotion_code": promotionCode}
+			}
+			delete(obj, "coupon")
+			delete(obj, "promotion_code")
+			return nil
+		},
+		view: func(m *stripeMock, obj map[string]interface{}) {
+			items := m.list("subscription_items", m.accounts["subscriptions/"+obj["id"].(string)], map[string]interface{}{"subscription": obj["id"], "limit": "100"}, nil)
+			data := items["data"].([]interface{})
+			// Items are listed in the order they were added.
+			for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+				data[i], data[j] = data[j], data[i]
+			}
+			obj["items"] = items
+		},
+		destroy: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error {
+			obj["status"] = "canceled"
+			obj["canceled_at"] = m.now()
+			obj["ended_at"] = m.now()
+			return nil
+		},
+		deletable: true,
+	})
+
+	m.register("subscription_items", &mockCollection{
+		object: "subscription_item",
+		prefix: "si_",
+		model:  stripe.SubscriptionItem{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"tax_rates": []interface{}{},
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			delete(obj, "proration_behavior")
+			if id, ok := obj["price"].(string); ok {
+				price, ok := m.objects["prices"][id]
+				if !ok {
+					return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such price: '%s'", id)}
+				}
+				for _, other := range m.objects["subscription_items"] {
+					if other["subscription"] == obj["subscription"] && other["id"] != obj["id"] && other["price"].(map[string]interface{})["id"] == id {
+						return mockInvalidRequest("Cannot add multiple subscription items with the same price: %s", id)
+					}
+				}
+				obj["price"] = mockCopy(price)
+			}
+			price := obj["price"].(map[string]interface{})
+			if recurring, ok := price["recurring"].(map[string]interface{}); ok && recurring["usage_type"] == "metered" {
+				delete(obj, "quantity")
+			} else {
+				mockSetDefault(obj, "quantity", int64(1))
+			}
+			return nil
+		},
+		destroy: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error {
+			for id, other := range m.objects["subscription_items"] {
+				if id != obj["id"] && other["subscription"] == obj["subscription"] {
+					m.remove("subscription_items", obj["id"].(string))
+					obj["deleted"] = true
+					return nil
+				}
+			}
+			return mockInvalidRequest("A subscription must have at least one active item.")
+		},
+		deletable: true,
+	})
+
+	m.register("subscription_schedules", &mockCollection{
+		object: "subscription_schedule",
+		prefix: "sub_sched_",
+		model:  stripe.SubscriptionSchedule{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"end_behavior": "release",
+				"status":       "not_started",
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			delete(obj, "proration_behavior")
+			delete(obj, "start_date")
+			delete(obj, "from_subscription")
+
+			start := m.now()
+			if created {
+				if id, ok := params["from_subscription"].(string); ok {
+					sub, ok := m.objects["subscriptions"][id]
+					if !ok {
+						return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such subscription: '%s'", id)}
+					}
+					var items []interface{}
+					for _, item := range m.list("subscription_items", m.account, map[string]interface{}{"subscription": id, "limit": "100"}, nil)["data"].([]interface{}) {
+						item := item.(map[string]interface{})
+						items = append(items, map[string]interface{}{"price": item["price"].(map[string]interface{})["id"], "quantity": item["quantity"]})
+					}
+					obj["customer"] = sub["customer"]
+					obj["subscription"] = id
+					obj["phases"] = []interface{}{map[string]interface{}{
+						"start_date": sub["current_period_start"],
+						"end_date":   sub["current_period_end"],
+						"items":      items,
+					}}
+				} else if startDate, ok := params["start_date"].(string); ok && startDate != "now" {
+					start, _ = strconv.ParseInt(startDate, 10, 64)
+				}
+			} else {
+				old := m.objects["subscription_schedules"][obj["id"].(string)]["phases"].([]interface{})
+				start, _ = old[0].(map[string]interface{})["start_date"].(int64)
+
+				// Phases that ended are kept as they are and can't be
+				// sent again.
+				if sent, ok := params["phases"]; ok && len(sent.([]interface{})) > 0 {
+					var ended []interface{}
+					for _, phase := range old {
+						if phase.(map[string]interface{})["end_date"].(int64) > m.now() {
+							break
+						}
+						ended = append(ended, mockCopy(phase))
+					}
+					phases := obj["phases"].([]interface{})
+					if len(ended) > 0 {
+						startDate, ok := phases[0].(map[string]interface{})["start_date"].(int64)
+						if ok && startDate < ended[len(ended)-1].(map[string]interface{})["end_date"].(int64) {
+							return mockInvalidRequest("You can not modify phases that have already ended.")
+						}
+						obj["phases"] = append(ended, phases...)
+					}
+				}
+			}
+
+			phases, _ := obj["phases"].([]interface{})
+			if len(phases) == 0 {
+				return mockInvalidRequest("Missing required param: phases.")
+			}
+
+			// Phases last for their iterations (30 days each here) unless
+			// they have an end date, the last one defaults to one iteration.
+			for i, phase := range phases {
+				phase := phase.(map[string]interface{})
+				if startDate, ok := phase["start_date"].(int64); ok && i == 0 {
+					start = startDate
+				}
+				phase["start_date"] = start
+
+				if _, ok := phase["end_date"].(int64); !ok {
+					iterations := int64(1)
+					if v, ok := phase["iterations"].(string); ok {
+						iterations, _ = strconv.ParseInt(v, 10, 64)
+					} else if i < len(phases)-1 {
+						return mockInvalidRequest("Phase %d must have an end_date or iterations.", i)
+					}
+					phase["end_date"] = start + iterations*30*24*60*60
+				}
+				if phase["end_date"].(int64) <= start {
+					return mockInvalidRequest("Phase %d's end_date must be after its start_date.", i)
+				}
+				delete(phase, "iterations")
+
+				if phase["trial"] == "true" {
+					phase["trial_end"] = phase["end_date"]
+				}
+				delete(phase, "trial")
+
+				mockSetDefault(phase, "proration_behavior", "create_prorations")
+				mockSetDefault(phase, "default_tax_rates", []interface{}{})
+				for _, item := range phase["items"].([]interface{}) {
+					item := item.(map[string]interface{})
+					if _, ok := m.objects["prices"][item["price"].(string)]; !ok {
+						return &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such price: '%s'", item["price"])}
+					}
+					mockSetDefault(item, "tax_rates", []interface{}{})
+				}
+				start = phase["end_date"].(int64)
+			}
+
+			first := phases[0].(map[string]interface{})
+			if obj["status"] == "not_started" && first["start_date"].(int64) <= m.now() {
+				obj["status"] = "active"
+				if obj["subscription"] == nil {
+					var items []interface{}
+					for _, item := range first["items"].([]interface{}) {
+						items = append(items, mockCopy(item))
+					}
+					sub, err := m.create("subscriptions", m.account, map[string]interface{}{"customer": obj["customer"], "items": items})
+					if err != nil {
+						return err
+					}
+					obj["subscription"] = sub["id"]
+				}
+			}
+			return nil
+		},
+		actions: map[string]func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error{
+			"cancel": func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error {
+				if id, ok := obj["subscription"].(string); ok {
+					sub := m.objects["subscriptions"][id]
+					sub["status"] = "canceled"
+					sub["canceled_at"] = m.now()
+				}
+				obj["status"] = "canceled"
+				obj["canceled_at"] = m.now()
+				return nil
+			},
+			"release": func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error {
+				obj["status"] = "released"
+				obj["released_at"] = m.now()
+				obj["released_subscription"] = obj["subscription"]
+				obj["subscription"] = nil
+				return nil
+			},
+		},
+	})
+
+	m.register("tax_rates", &mockCollection{
+		object: "tax_rate",
+		prefix: "txr_",
+		model:  stripe.TaxRate{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"active": true,
+			}
+		},
+	})
+
+	m.register("webhook_endpoints", &mockCollection{
+		object: "webhook_endpoint",
+		prefix: "we_",
+		model:  stripe.WebhookEndpoint{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"status":      "enabled",
+				"api_version": stripe.APIVersion,
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			if created {
+				obj["secret"] = "whsec_" + mockRandomString(24)
+				if obj["connect"] == true {
+					obj["application"] = "ca_" + mockRandomString(14)
+				}
+			} else if _, ok := params["api_version"]; ok {
+				return mockInvalidRequest("Received unknown parameter: api_version")
+			}
+			if disabled, ok := obj["disabled"]; ok {
+				if disabled == true || disabled == "true" {
+					obj["status"] = "disabled"
+				} else {
+					obj["status"] = "enabled"
+				}
+			}
+			delete(obj, "connect")
+			delete(obj, "disabled")
+			return nil
+		},
+		createOnly: []string{"secret"},
+		actions: map[string]func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error{
+			"roll_secret": func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}) error {
+				obj["secret"] = "whsec_" + mockRandomString(24)
+				return nil
+			},
+		},
+		deletable: true,
+	})
+
+	m.register("billing_portal/configurations", &mockCollection{
+		object: "billing_portal.configuration",
+		prefix: "bpc_",
+		model:  stripe.BillingPortalConfiguration{},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"active":     true,
+				"is_default": false,
+			}
+		},
+		write: func(m *stripeMock, obj map[string]interface{}, params map[string]interface{}, created bool) error {
+			// Features and the business profile are always returned in full,
+			// and merged on update.
+			businessProfile := map[string]interface{}{
+				"headline":             nil,
+				"privacy_policy_url":   nil,
+				"terms_of_service_url": nil,
+			}
+			features := map[string]interface{}{
+				"customer_update":       map[string]interface{}{"allowed_updates": []interface{}{}, "enabled": false},
+				"invoice_history":       map[string]interface{}{"enabled": false},
+				"payment_method_update": map[string]interface{}{"enabled": false},
+				"subscription_cancel": map[string]interface{}{
+					"cancellation_reason": map[string]interface{}{"enabled": false, "options": []interface{}{}},
+					"enabled":             false,
+					"mode":                "at_period_end",
+					"proration_behavior":  "none",
+				},
+				"subscription_pause": map[string]interface{}{"enabled": false},
+				"subscription_update": map[string]interface{}{
+					"default_allowed_updates": []interface{}{},
+					"enabled":                 false,
+					"products":                []interface{}{},
+					"proration_behavior":      "none",
+				},
+			}
+			if old, ok := m.objects["billing_portal/configurations"][obj["id"].(string)]; ok {
+				businessProfile = mockCopy(old["business_profile"]).(map[string]interface{})
+				features = mockCopy(old["features"]).(map[string]interface{})
+			}
+			if v, ok := params["business_profile"].(map[string]interface{}); ok {
+				mockDeepMerge(businessProfile, v)
+			}
+			if v, ok := params["features"].(map[string]interface{}); ok {
+				mockDeepMerge(features, mockCoerce(v, reflect.TypeOf(stripe.BillingPortalConfigurationFeatures{}), false).(map[string]interface{}))
+			}
+			obj["business_profile"] = businessProfile
+			obj["features"] = features
+
+			loginPage := map[string]interface{}{"enabled": false, "url": nil}
+			if old, ok := m.objects["billing_portal/configurations"][obj["id"].(string)]; ok {
+				loginPage = mockCopy(old["login_page"]).(map[string]interface{})
+			}
+			if v, ok := params["login_page"].(map[string]interface{}); ok {
+				enabled := v["enabled"] == "true"
+				if enabled && loginPage["url"] == nil {
+					loginPage["url"] = "https://billing.stripe.com/p/login/test_" + mockRandomString(24)
+				} else if !enabled {
+					loginPage["url"] = nil
+				}
+				loginPage["enabled"] = enabled
+			}
+			obj["login_page"] = loginPage
+
+			obj["updated"] = m.now()
+			return nil
+		},
+	})
+
+	m.server = httptest.NewServer(m)
+	return m
+}
+
+func (m *stripeMock) register(path string, c *mockCollection) {
+	m.collections[path] = c
+	m.objects[path] = map[string]map[string]interface{}{}
+}
+
+// URL returns the base URL to configure stripe-go backends with.
+func (m *stripeMock) URL() string {
+	return m.server.URL
+}
+
+func (m *stripeMock) Close() {
+	m.server.Close()
+}
+
+// Object returns a copy of a stored object, or nil when it does not exist.
+func (m *stripeMock) Object(path, id string) map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	obj, ok := m.objects[path][id]
+	if !ok {
+		return nil
+	}
+	return mockCopy(obj).(map[string]interface{})
+}
+
+// Objects returns copies of the stored objects of a collection.
+func (m *stripeMock) Objects(path string) []map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var objects []map[string]interface{}
+	for _, id := range m.order[path] {
+		if obj, ok := m.objects[path][id]; ok {
+			objects = append(objects, mockCopy(obj).(map[string]interface{}))
+		}
+	}
+	return objects
+}
+
+// LoseNextResponse makes the next POST request go through while failing, so
+// that its outcome is unknown to the client.
+func (m *stripeMock) LoseNextResponse() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.loseResponses++
+}
+
+// Put stores an object as is, mimicking changes made outside of Terraform.
+func (m *stripeMock) Put(path, id string, attributes map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	obj, ok := m.objects[path][id]
+	if !ok {
+		panic(fmt.Sprintf("stripe mock: no such %s: %s", path, id))
+	}
+	mockMerge(obj, attributes)
+}
+
+// Requests returns the requests received so far.
+func (m *stripeMock) Requests() []*http.Request {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]*http.Request(nil), m.requests...)
+}
+
+// now follows the wall clock, which the provider compares dates to, and keeps
+// increasing between requests.
+func (m *stripeMock) now() int64 {
+	return time.Now().Unix() + m.seq
+}
+
+func (m *stripeMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, r)
+
+	var key, request string
+	if r.Method == http.MethodPost && r.Header.Get("Idempotency-Key") != "" {
+		r.ParseForm()
+		key = r.Header.Get("Stripe-Account") + "/" + r.Header.Get("Idempotency-Key")
+		request = r.URL.Path + "?" + r.PostForm.Encode()
+
+		if replay, ok := m.idempotent[key]; ok {
+			if replay.request != request {
+				mockWriteError(w, &mockError{status: http.StatusBadRequest, code: "idempotency_key_in_use", message: "Keys for idempotent requests can only be used with the same parameters they were first used with."})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(replay.status)
+			w.Write(replay.body)
+			return
+		}
+	}
+
+	status, body := m.serve(r)
+	if err, ok := body.(error); ok {
+		mockErr, ok := err.(*mockError)
+		if !ok {
+			mockErr = &mockError{status: http.StatusBadRequest, code: "parameter_invalid", message: err.Error()}
+		}
+		mockWriteError(w, mockErr)
+		return
+	}
+
+	encoded, _ := json.Marshal(body)
+	if key != "" {
+		m.idempotent[key] = &mockResponse{request: request, status: status, body: encoded}
+	}
+
+	if r.Method == http.MethodPost && m.loseResponses > 0 {
+		m.loseResponses--
+		mockWriteError(w, &mockError{status: http.StatusInternalServerError, code: "connection_lost", message: "The connection was lost"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(encoded)
+}
+
+func mockWriteError(w http.ResponseWriter, err *mockError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":    "invalid_request_error",
+			"code":    err.code,
+			"message": err.message,
+		},
+	})
+}
+
+func (m *stripeMock) serve(r *http.Request) (int, interface{}) {
+	if err := r.ParseForm(); err != nil {
+		return 0, err
+	}
+	// ParseForm ignores the body of DELETE requests, stripe-go sends their
+	// parameters there.
+	if r.Method == http.MethodDelete {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return 0, err
+		}
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return 0, err
+		}
+		for k, v := range values {
+			r.Form[k] = append(r.Form[k], v...)
+		}
+	}
+	params := mockParseForm(r.Form)
+
+	collectionPath, parentID, rest := m.route(strings.TrimPrefix(r.URL.Path, "/v1/"))
+	if collectionPath == "" {
+		return 0, &mockError{status: http.StatusNotFound, code: "resource_missing", message: "Unrecognized request URL: " + r.URL.Path}
+	}
+
+	c := m.collections[collectionPath]
+	account := r.Header.Get("Stripe-Account")
+	m.account = account
+	expand := mockExpand(params)
+	delete(params, "expand")
+	if c.parent != "" {
+		params[c.parent] = parentID
+	}
+
+	if rest[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, m.list(collectionPath, account, params, expand)
+		case http.MethodPost:
+			obj, err := m.create(collectionPath, account, params)
+			if err != nil {
+				return 0, err
+			}
+			return http.StatusOK, m.render(c, obj, expand, true)
+		}
+		return 0, &mockError{status: http.StatusMethodNotAllowed, code: "method_not_allowed", message: r.Method}
+	}
+
+	id := rest[0]
+	obj, ok := m.objects[collectionPath][id]
+	if !ok || m.accounts[collectionPath+"/"+id] != account || (c.parent != "" && obj[c.parent] != parentID) {
+		return 0, &mockError{status: http.StatusNotFound, code: "resource_missing", message: fmt.Sprintf("No such %s: '%s'", c.object, id)}
+	}
+
+	if len(rest) > 1 {
+		action, ok := c.actions[rest[1]]
+		if !ok || r.Method != http.MethodPost {
+			return 0, &mockError{status: http.StatusNotFound, code: "resource_missing", message: "Unrecognized request URL: " + r.URL.Path}
+		}
+		if err := action(m, obj, params); err != nil {
+			return 0, err
+		}
+		return http.StatusOK, m.render(c, obj, expand, true)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, m.render(c, obj, expand, false)
+	case http.MethodPost:
+		if err := m.update(collectionPath, obj, params); err != nil {
+			return 0, err
+		}
+		return http.StatusOK, m.render(c, obj, expand, false)
+	case http.MethodDelete:
+		if !c.deletable {
+			return 0, &mockError{status: http.StatusNotFound, code: "resource_missing", message: "Unrecognized request URL: " + r.URL.Path}
+		}
+		if c.destroy != nil {
+			m.seq++
+			if err := c.destroy(m, obj, params); err != nil {
+				return 0, err
+			}
+			return http.StatusOK, m.render(c, obj, expand, false)
+		}
+		m.remove(collectionPath, id)
+		return http.StatusOK, map[string]interface{}{"id": id, "object": c.object, "deleted": true}
+	}
+
+	return 0, &mockError{status: http.StatusMethodNotAllowed, code: "method_not_allowed", message: r.Method}
+}
+
+// route finds the collection a request path belongs to, and returns the rest
+// of the path. Nested collections are registered with a "*" standing for the
+// ID of their parent, e.g. "customers/*/tax_ids".
+func (m *stripeMock) route(path string) (collectionPath, parentID string, rest []string) {
+	segments := strings.Split(path, "/")
+	longest := 0
+	for p := range m.collections {
+		pattern := strings.Split(p, "/")
+		if len(pattern) <= longest || len(pattern) > len(segments) {
+			continue
+		}
+
+		matched, parent := true, ""
+		for i, part := range pattern {
+			if part == "*" {
+				parent = segments[i]
+			} else if part != segments[i] {
+				matched = false
+			}
+		}
+
+		if matched {
+			longest, collectionPath, parentID, rest = len(pattern), p, parent, segments[len(pattern):]
+		}
+	}
+
+	if len(rest) == 0 {
+		rest = []string{""}
+	}
+	return collectionPath, parentID, rest
+}
+
+func (m *stripeMock) create(path, account string, params map[string]interface{}) (map[string]interface{}, error) {
+	c := m.collections[path]
+	m.seq++
+
+	obj := map[string]interface{}{}
+	if c.defaults != nil {
+		obj = c.defaults()
+	}
+	obj["object"] = c.object
+	obj["created"] = m.now()
+	obj["livemode"] = false
+	obj["metadata"] = map[string]interface{}{}
+
+	id, _ := params["id"].(string)
+	if id == "" {
+		id = c.prefix + mockRandomString(14)
+	}
+	if _, exists := m.objects[path][id]; exists {
+		return nil, mockInvalidRequest("%s already exists: %s", c.object, id)
+	}
+
+	mockMerge(obj, mockCoerce(params, reflect.TypeOf(c.model), false).(map[string]interface{}))
+	obj["id"] = id
+
+	if c.write != nil {
+		if err := c.write(m, obj, params, true); err != nil {
+			return nil, err
+		}
+	}
+
+	m.objects[path][id] = obj
+	m.order[path] = append(m.order[path], id)
+	if account != "" {
+		m.accounts[path+"/"+id] = account
+	}
+	return obj, nil
+}
+
+func (m *stripeMock) update(path string, obj map[string]interface{}, params map[string]interface{}) error {
+	c := m.collections[path]
+	m.seq++
+
+	updated := mockCopy(obj).(map[string]interface{})
+	mockMerge(updated, mockCoerce(params, reflect.TypeOf(c.model), false).(map[string]interface{}))
+
+	if c.write != nil {
+		if err := c.write(m, updated, params, false); err != nil {
+			return err
+		}
+	}
+
+	for k := range obj {
+		delete(obj, k)
+	}
+	for k, v := range updated {
+		obj[k] = v
+	}
+	return nil
+}
+
+func (m *stripeMock) remove(path, id string) {
+	delete(m.objects[path], id)
+	delete(m.accounts, path+"/"+id)
+	order := m.order[path][:0]
+	for _, v := range m.order[path] {
+		if v != id {
+			order = append(order, v)
+		}
+	}
+	m.order[path] = order
+}
+
+func (m *stripeMock) list(path, account string, params map[string]interface{}, expand map[string]bool) map[string]interface{} {
+	c := m.collections[path]
+
+	limit := 10
+	if l, ok := params["limit"].(string); ok {
+		limit, _ = strconv.Atoi(l)
+	}
+	startingAfter, _ := params["starting_after"].(string)
+	for _, k := range []string{"limit", "starting_after", "ending_before", "created"} {
+		delete(params, k)
+	}
+
+	itemExpand := map[string]bool{}
+	for k := range expand {
+		itemExpand[strings.TrimPrefix(k, "data.")] = true
+	}
+
+	// Objects are listed newest first, like the real API does.
+	var matches []map[string]interface{}
+	ids := m.order[path]
+	for i := len(ids) - 1; i >= 0; i-- {
+		obj := m.objects[path][ids[i]]
+		if m.accounts[path+"/"+ids[i]] == account && mockMatches(obj, params) {
+			matches = append(matches, obj)
+		}
+	}
+	if c.ascending {
+		for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+			matches[i], matches[j] = matches[j], matches[i]
+		}
+	}
+
+	if startingAfter != "" {
+		for i, obj := range matches {
+			if obj["id"] == startingAfter {
+				matches = matches[i+1:]
+				break
+			}
+		}
+	}
+
+	hasMore := len(matches) > limit
+	if hasMore {
+		matches = matches[:limit]
+	}
+
+	data := make([]interface{}, len(matches))
+	for i, obj := range matches {
+		data[i] = m.render(c, obj, itemExpand, false)
+	}
+
+	return map[string]interface{}{
+		"object":   "list",
+		"url":      "/v1/" + path,
+		"has_more": hasMore,
+		"data":     data,
+	}
+}
+
+func (m *stripeMock) render(c *mockCollection, obj map[string]interface{}, expand map[string]bool, created bool) map[string]interface{} {
+	out := mockCopy(obj).(map[string]interface{})
+	if c.view != nil {
+		c.view(m, out)
+	}
+	for _, k := range c.expandOnly {
+		if !expand[k] {
+			delete(out, k)
+		}
+	}
+	if !created {
+		for _, k := range c.createOnly {
+			delete(out, k)
+		}
+	}
+	return out
+}
+
+func mockMatches(obj map[string]interface{}, filters map[string]interface{}) bool {
+	for k, want := range filters {
+		switch k {
+		case "lookup_keys":
+			if !mockContains(want, obj["lookup_key"]) {
+				return false
+			}
+		case "ids":
+			if !mockContains(want, obj["id"]) {
+				return false
+			}
+		default:
+			switch want := want.(type) {
+			case map[string]interface{}:
+				got, ok := obj[k].(map[string]interface{})
+				if !ok || !mockMatches(got, want) {
+					return false
+				}
+			default:
+				if obj[k] == nil || fmt.Sprint(obj[k]) != fmt.Sprint(want) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func mockContains(list interface{}, v interface{}) bool {
+	items, _ := list.([]interface{})
+	for _, item := range items {
+		if fmt.Sprint(item) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func mockExpand(params map[string]interface{}) map[string]bool {
+	expand := map[string]bool{}
+	if items, ok := params["expand"].([]interface{}); ok {
+		for _, item := range items {
+			expand[item.(string)] = true
+		}
+	}
+	return expand
+}
+
+func mockSetDefault(obj map[string]interface{}, key string, value interface{}) {
+	if obj[key] == nil {
+		obj[key] = value
+	}
+}
+
+// mockDeriveTiers fills in the amounts of price and plan tiers the way the
+// API does: the last tier's `up_to` is null ("inf" doesn't coerce into an
+// integer), and integer amounts come with their decimal counterpart. Tiers
+// need an amount, a free tier has a unit amount of 0.
+func mockDeriveTiers(obj map[string]interface{}) error {
+	tiers, ok := obj["tiers"].([]interface{})
+	if !ok {
+		return nil
+	}
+	for i, t := range tiers {
+		tier := t.(map[string]interface{})
+		if tier["flat_amount"] == nil && tier["flat_amount_decimal"] == nil && tier["unit_amount"] == nil && tier["unit_amount_decimal"] == nil {
+			return mockInvalidRequest("Tier %d must have one of `flat_amount` or `unit_amount` set.", i)
+		}
+		mockSetDefault(tier, "up_to", nil)
+		for _, k := range []string{"flat_amount", "unit_amount"} {
+			if amount, ok := tier[k].(int64); ok {
+				tier[k+"_decimal"] = strconv.FormatInt(amount, 10)
+			}
+			mockSetDefault(tier, k, nil)
+			mockSetDefault(tier, k+"_decimal", nil)
+		}
+	}
+	return nil
+}
+
+// mockCurrencyOptions returns the `currency_options` of an object, dropping
+// the currencies that were unset.
+func mockCurrencyOptions(obj map[string]interface{}) map[string]interface{} {
+	options, ok := obj["currency_options"].(map[string]interface{})
+	if !ok {
+		options = map[string]interface{}{}
+		obj["currency_options"] = options
+	}
+	for currency, option := range options {
+		if option == nil {
+			delete(options, currency)
+		}
+	}
+	return options
+}
+
+// mockParseForm turns `a[b][0]=c` style parameters into nested maps and
+// slices.
+func mockParseForm(values url.Values) map[string]interface{} {
+	root := map[string]interface{}{}
+	for key, vs := range values {
+		parts := strings.Split(strings.Replace(key, "]", "", -1), "[")
+		node := root
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := node[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				node[part] = next
+			}
+			node = next
+		}
+		node[parts[len(parts)-1]] = vs[len(vs)-1]
+	}
+	return mockListify(root).(map[string]interface{})
+}
+
+func mockListify(v interface{}) interface{} {
+	node, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	indexes := make([]int, 0, len(node))
+	for k, child := range node {
+		node[k] = mockListify(child)
+		if i, err := strconv.Atoi(k); err == nil {
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(node) == 0 || len(indexes) != len(node) {
+		return node
+	}
+
+	sort.Ints(indexes)
+	list := make([]interface{}, len(indexes))
+	for i, index := range indexes {
+		list[i] = node[strconv.Itoa(index)]
+	}
+	return list
+}
+
+// mockCoerce converts string parameters into the JSON types expected by the
+// given stripe-go model type.
+func mockCoerce(v interface{}, t reflect.Type, asString bool) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			switch t.Kind() {
+			case reflect.Struct:
+				if f, ok := mockField(t, k); ok {
+					out[k] = mockCoerce(child, f.Type, strings.HasSuffix(f.Tag.Get("json"), ",string"))
+				} else {
+					out[k] = child
+				}
+			case reflect.Map:
+				out[k] = mockCoerce(child, t.Elem(), false)
+			default:
+				out[k] = child
+			}
+		}
+		return out
+	case []interface{}:
+		if t.Kind() != reflect.Slice {
+			return val
+		}
+		out := make([]interface{}, len(val))
+		for i, child := range val {
+			out[i] = mockCoerce(child, t.Elem(), false)
+		}
+		return out
+	case string:
+		if asString {
+			return val
+		}
+		switch t.Kind() {
+		case reflect.Int, reflect.Int64:
+			n, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return nil
+			}
+			return n
+		case reflect.Float64:
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil
+			}
+			return f
+		case reflect.Bool:
+			b, _ := strconv.ParseBool(val)
+			return b
+		case reflect.Slice:
+			if val == "" {
+				return []interface{}{}
+			}
+		case reflect.Map:
+			if val == "" {
+				return map[string]interface{}{}
+			}
+		case reflect.Struct:
+			if val == "" {
+				return nil
+			}
+		}
+		return val
+	}
+	return v
+}
+
+func mockField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if embedded, ok := mockField(f.Type, name); ok {
+				return embedded, true
+			}
+			continue
+		}
+		if strings.Split(f.Tag.Get("json"), ",")[0] == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// mockMerge applies src onto dst. Metadata keys set to an empty string are
+// removed, like the API does.
+func mockMerge(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if k == "metadata" {
+			metadata, ok := dst[k].(map[string]interface{})
+			if !ok {
+				metadata = map[string]interface{}{}
+				dst[k] = metadata
+			}
+			entries, _ := v.(map[string]interface{})
+			if len(entries) == 0 {
+				continue
+			}
+			for mk, mv := range entries {
+				if mv == "" {
+					delete(metadata, mk)
+				} else {
+					metadata[mk] = mv
+				}
+			}
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// mockDeepMerge merges nested hashes the way Stripe does for attributes such
+// as the customer portal's features.
+func mockDeepMerge(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if child, ok := v.(map[string]interface{}); ok {
+			if existing, ok := dst[k].(map[string]interface{}); ok {
+				mockDeepMerge(existing, child)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+func mockCopy(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			out[k] = mockCopy(child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, child := range val {
+			out[i] = mockCopy(child)
+		}
+		return out
+	}
+	return v
+}
+
+func mockRandomString(n int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = letters[rand.Intn(len(letters))]
+	}
+	return string(b)
+}
@@ -1,6 +1,8 @@
 package stripe
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -46,6 +48,99 @@ func expandStringList(d *schema.ResourceData, key string) []*string {
 	return nil
 }
 
+// expandStringSlice is expandStringList for lists nested in blocks.
+func expandStringSlice(in []interface{}) []*string {
+	if len(in) == 0 {
+		return nil
+	}
+
+	expanded := make([]*string, len(in))
+	for i, element := range in {
+		tmp := element.(string)
+		expanded[i] = &tmp
+	}
+	return expanded
+}
+
+// expandStringSet is expandStringList for sets of strings.
+func expandStringSet(in *schema.Set) []*string {
+	return expandStringSlice(in.List())
+}
+
+// expandEnabled returns the `enabled` attribute of a single
+// `{ enabled = ... }` block.
+func expandEnabled(in []interface{}) bool {
+	if len(in) == 0 || in[0] == nil {
+		return false
+	}
+	return in[0].(map[string]interface{})["enabled"].(bool)
+}
+
+func flattenEnabled(enabled bool) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"enabled": enabled,
+		},
+	}
+}
+
+// expandTimestamp converts an RFC3339 timestamp into the Unix timestamp
+// expected by Stripe.
+func expandTimestamp(v string) (int64, error) {
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+func flattenTimestamp(v int64) string {
+	if v == 0 {
+		return ""
+	}
+	return time.Unix(v, 0).UTC().Format(time.RFC3339)
+}
+
+// suppressEquivalentTimestamps ignores differences between two RFC3339
+// timestamps denoting the same instant, e.g. in different time zones.
+func suppressEquivalentTimestamps(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return o.Equal(n)
+}
+
+// derivedDecimal returns the decimal counterpart of an amount, unless it was
+// merely derived by Stripe from the whole amount.
+func derivedDecimal(amount int64, decimal float64) float64 {
+	if amount != 0 && decimal == float64(amount) {
+		return 0
+	}
+	return decimal
+}
+
+// removedCurrencies returns the currencies of a `currency_options` set that
+// are no longer part of it.
+func removedCurrencies(old, new *schema.Set) []string {
+	current := map[string]bool{}
+	for _, v := range new.List() {
+		current[v.(map[string]interface{})["currency"].(string)] = true
+	}
+
+	var removed []string
+	for _, v := range old.List() {
+		if currency := v.(map[string]interface{})["currency"].(string); !current[currency] {
+			removed = append(removed, currency)
+		}
+	}
+	return removed
+}
+
 func getMapKeys(m map[string]bool) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -53,3 +148,85 @@ func getMapKeys(m map[string]bool) []string {
 	}
 	return keys
 }
+
+func metadataMatches(want map[string]interface{}, got map[string]string) bool {
+	for k, v := range want {
+		if got[k] != v.(string) {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceOnlyArguments only affect how resources are managed, and are left
+// out of data sources.
+var resourceOnlyArguments = map[string]bool{
+	"on_destroy":          true,
+	"transfer_lookup_key": true,
+}
+
+// dataSourceSchemaFromResourceSchema derives a data source schema from a
+// resource schema, turning every attribute into a computed one.
+func dataSourceSchemaFromResourceSchema(rs map[string]*schema.Schema) map[string]*schema.Schema {
+	ds := make(map[string]*schema.Schema, len(rs))
+	for k, v := range rs {
+		if resourceOnlyArguments[k] {
+			continue
+		}
+
+		dv := &schema.Schema{
+			Type:        v.Type,
+			Set:         v.Set,
+			Computed:    true,
+			Sensitive:   v.Sensitive,
+			Description: v.Description,
+		}
+
+		switch elem := v.Elem.(type) {
+		case *schema.Resource:
+			dv.Elem = &schema.Resource{
+				Schema: dataSourceSchemaFromResourceSchema(elem.Schema),
+			}
+		case *schema.Schema:
+			dv.Elem = &schema.Schema{Type: elem.Type}
+		}
+
+		ds[k] = dv
+	}
+	return ds
+}
+
+// addOptionalFieldsToSchema makes the given computed attributes settable so
+// that they can be used as lookup criteria.
+func addOptionalFieldsToSchema(s map[string]*schema.Schema, keys ...string) {
+	for _, k := range keys {
+		s[k].Optional = true
+	}
+}
+
+// flattenToMap runs a resource flattener against a scratch ResourceData and
+// returns the resulting attributes, so that objects can be nested in lists.
+func flattenToMap(r *schema.Resource, id string, flatten func(*schema.ResourceData)) map[string]interface{} {
+	rd := r.Data(nil)
+	rd.SetId(id)
+	flatten(rd)
+
+	out := map[string]interface{}{"id": id}
+	for k := range r.Schema {
+		if !resourceOnlyArguments[k] {
+			out[k] = rd.Get(k)
+		}
+	}
+	return out
+}
+
+// dataSourceListElem describes one element of the objects returned by a
+// plural data source.
+func dataSourceListElem(r *schema.Resource) *schema.Resource {
+	elemSchema := dataSourceSchemaFromResourceSchema(r.Schema)
+	elemSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	return &schema.Resource{Schema: elemSchema}
+}